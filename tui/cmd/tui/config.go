@@ -0,0 +1,438 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"mcf-dev/tui/internal/config"
+	"mcf-dev/tui/internal/mcf"
+	"mcf-dev/tui/internal/ui"
+)
+
+const configUsage = `usage: mcf-tui config show [--json] [--quiet]
+       mcf-tui config search [--json] [--quiet] <query>
+       mcf-tui config repair [--yes] [--quiet]
+       mcf-tui config export [--include-secrets] [--quiet] <file>
+       mcf-tui config import [--layer global|project|local] [--quiet] <file>
+       mcf-tui config edit [--layer global|project|local] [--quiet]`
+
+// runConfig handles the `config` subcommand
+func runConfig(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, configUsage)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "show":
+		return runConfigShow(args[1:])
+	case "search":
+		return runConfigSearch(args[1:])
+	case "repair":
+		return runConfigRepair(args[1:])
+	case "export":
+		return runConfigExport(args[1:])
+	case "import":
+		return runConfigImport(args[1:])
+	case "edit":
+		return runConfigEdit(args[1:])
+	}
+
+	fmt.Fprintln(os.Stderr, configUsage)
+	return exitUsage
+}
+
+// loadCLISettings finds the MCF root above the working directory and reads settings.json
+func loadCLISettings() (string, map[string]interface{}, int) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return "", nil, exitFailure
+	}
+
+	mcfRoot, err := mcf.FindMCFRoot(cwd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return "", nil, exitNotInitialized
+	}
+
+	settings, err := mcf.LoadRawSettings(mcfRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to read %s: %v\n", mcf.SettingsPath(mcfRoot), err)
+		if os.IsNotExist(err) {
+			return "", nil, exitNotInitialized
+		}
+		return "", nil, exitUsage
+	}
+
+	return mcfRoot, settings, exitOK
+}
+
+func runConfigShow(args []string) int {
+	fs, quietFlag := newFlagSet("config show")
+	jsonFlag := fs.Bool("json", false, "Print configuration as JSON")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	mcfRoot, settings, code := loadCLISettings()
+	if code != exitOK {
+		return code
+	}
+
+	if *jsonFlag {
+		output, err := mcf.FormatSettingsJSON(settings)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+		fmt.Print(output)
+		return exitOK
+	}
+
+	output, err := mcf.FormatSettingsYAML(settings)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if *quietFlag {
+		fmt.Print(output)
+		return exitOK
+	}
+
+	fmt.Printf("# %s\n", mcf.SettingsPath(mcfRoot))
+	fmt.Println(ui.HighlightYAML(output, ui.NewTheme()))
+	return exitOK
+}
+
+func runConfigSearch(args []string) int {
+	fs, quietFlag := newFlagSet("config search")
+	jsonFlag := fs.Bool("json", false, "Print matches as JSON")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, configUsage)
+		return exitUsage
+	}
+
+	_, settings, code := loadCLISettings()
+	if code != exitOK {
+		return code
+	}
+
+	matches := mcf.SearchSettings(settings, fs.Arg(0))
+
+	if *jsonFlag {
+		data, err := json.MarshalIndent(matches, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+		fmt.Println(string(data))
+		return exitOK
+	}
+
+	if len(matches) == 0 {
+		if !*quietFlag {
+			fmt.Fprintf(os.Stderr, "No settings match %q\n", fs.Arg(0))
+		}
+		return exitOK
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if !*quietFlag {
+		fmt.Fprintln(w, "KEY\tVALUE")
+	}
+	for _, m := range matches {
+		fmt.Fprintf(w, "%s\t%s\n", m.Key, mcf.FormatSettingValue(m.Value))
+	}
+	w.Flush()
+	return exitOK
+}
+
+// runConfigRepair restores corrupt TUI config files from their latest backup, after
+// confirmation, then fills missing required keys in settings.json, keeping user values
+func runConfigRepair(args []string) int {
+	fs, quietFlag := newFlagSet("config repair")
+	yesFlag := fs.Bool("yes", false, "Restore corrupt config files from backup without asking")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	mcfRoot, _, code := loadCLISettings()
+	if code != exitOK {
+		return code
+	}
+	if code := restoreCorruptLayers(mcfRoot, *yesFlag, *quietFlag); code != exitOK {
+		return code
+	}
+
+	backupPath, repaired, err := mcf.RepairSettings(mcfRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	if len(repaired) == 0 {
+		if !*quietFlag {
+			fmt.Println("settings.json has all required keys; nothing to repair")
+		}
+		return exitOK
+	}
+
+	for _, issue := range repaired {
+		fmt.Printf("repaired %s\n", issue)
+	}
+	if !*quietFlag {
+		fmt.Printf("backup saved to %s\n", backupPath)
+	}
+	return exitOK
+}
+
+// restoreCorruptLayers loads the layered TUI configuration and, while a layer
+// fails to parse, offers to restore that layer from its most recent backup
+func restoreCorruptLayers(mcfRoot string, yes, quiet bool) int {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	layered := config.NewLayeredConfig(config.DefaultLayerPaths(home, mcfRoot), nil)
+	input := bufio.NewReader(os.Stdin)
+	for {
+		err := layered.Load()
+		if err == nil {
+			return exitOK
+		}
+		var layerErr *config.LayerError
+		if !errors.Is(err, config.ErrCorruptConfig) || !errors.As(err, &layerErr) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+
+		manager, _ := layered.Layer(layerErr.Layer)
+		backup := manager.LatestBackup()
+		if backup == "" {
+			fmt.Fprintf(os.Stderr, "Error: %v\nNo backup to restore; fix or delete the file by hand.\n", err)
+			return exitUsage
+		}
+		prompt := fmt.Sprintf("The %s config is corrupt. Restore it from %s? [y/N] ", layerErr.Layer, backup)
+		if !yes && !confirm(input, prompt) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitUsage
+		}
+		if _, err := manager.RestoreLatestBackup(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to restore %s: %v\n", backup, err)
+			return exitFailure
+		}
+		if !quiet {
+			fmt.Printf("restored %s config from %s\n", layerErr.Layer, backup)
+		}
+	}
+}
+
+// loadCLILayers finds the MCF root and loads the layered TUI configuration for it
+func loadCLILayers() (*config.LayeredConfig, int) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return nil, exitFailure
+	}
+	mcfRoot, err := mcf.FindMCFRoot(cwd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return nil, exitNotInitialized
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return nil, exitFailure
+	}
+
+	layered := config.NewLayeredConfig(config.DefaultLayerPaths(home, mcfRoot), nil)
+	if err := layered.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if errors.Is(err, config.ErrCorruptConfig) {
+			fmt.Fprintln(os.Stderr, "Run `mcf-tui config repair` to restore the latest backup.")
+		}
+		return nil, exitUsage
+	}
+	return layered, exitOK
+}
+
+// runConfigExport writes the merged TUI configuration to a shareable YAML bundle
+func runConfigExport(args []string) int {
+	fs, quietFlag := newFlagSet("config export")
+	secretsFlag := fs.Bool("include-secrets", false, "Keep API keys, tokens and passwords in the bundle")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, configUsage)
+		return exitUsage
+	}
+
+	layered, code := loadCLILayers()
+	if code != exitOK {
+		return code
+	}
+	if err := layered.ExportConfig(fs.Arg(0), *secretsFlag); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if *quietFlag {
+		return exitOK
+	}
+	fmt.Printf("exported configuration to %s\n", fs.Arg(0))
+	if !*secretsFlag {
+		fmt.Println("secrets were redacted; pass --include-secrets to keep them")
+	}
+	return exitOK
+}
+
+// runConfigImport merges a bundle into one layer of the TUI configuration
+func runConfigImport(args []string) int {
+	fs, quietFlag := newFlagSet("config import")
+	layerFlag := fs.String("layer", string(config.LayerProject), "Layer to merge the bundle into: global, project or local")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, configUsage)
+		return exitUsage
+	}
+
+	layered, code := loadCLILayers()
+	if code != exitOK {
+		return code
+	}
+	if _, err := layered.Layer(config.Layer(*layerFlag)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+	if err := layered.ImportConfig(fs.Arg(0), config.Layer(*layerFlag)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if errors.Is(err, config.ErrBundleVersion) || errors.Is(err, config.ErrInvalidConfig) {
+			return exitUsage
+		}
+		return exitFailure
+	}
+
+	if !*quietFlag {
+		fmt.Printf("imported %s into the %s config\n", fs.Arg(0), *layerFlag)
+	}
+	return exitOK
+}
+
+// runConfigEdit opens one layer of the TUI configuration in $EDITOR and applies
+// the edited text only if it parses and validates. A rejected edit is kept in a
+// temp file so it is not lost.
+func runConfigEdit(args []string) int {
+	fs, quietFlag := newFlagSet("config edit")
+	layerFlag := fs.String("layer", string(config.LayerGlobal), "Layer to edit: global, project or local")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 0 {
+		fmt.Fprintln(os.Stderr, configUsage)
+		return exitUsage
+	}
+
+	layered, code := loadCLILayers()
+	if code != exitOK {
+		return code
+	}
+	layer := config.Layer(*layerFlag)
+	manager, err := layered.Layer(layer)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	original, err := manager.Raw()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	// Keep the extension so the editor highlights the right format
+	tmp, err := os.CreateTemp("", "mcf-tui-*"+filepath.Ext(manager.Path()))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	tmpPath := tmp.Name()
+	_, err = tmp.WriteString(original)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if err := openEditor(tmpPath); err != nil {
+		os.Remove(tmpPath)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	edited, err := os.ReadFile(tmpPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	if string(edited) == original {
+		os.Remove(tmpPath)
+		if !*quietFlag {
+			fmt.Println("no changes")
+		}
+		return exitOK
+	}
+
+	if err := layered.ApplyRaw(layer, string(edited)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "%s was not changed; your edit is saved in %s\n", manager.Path(), tmpPath)
+		if errors.Is(err, config.ErrInvalidConfig) {
+			return exitUsage
+		}
+		return exitFailure
+	}
+	os.Remove(tmpPath)
+
+	if !*quietFlag {
+		fmt.Printf("saved %s config to %s\n", layer, manager.Path())
+	}
+	return exitOK
+}
+
+// openEditor runs $VISUAL or $EDITOR (falling back to vi) on path, attached to the terminal
+func openEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// The variable may carry arguments, e.g. "code --wait"
+	fields := strings.Fields(editor)
+	cmd := exec.Command(fields[0], append(fields[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", fields[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"mcf-dev/tui/internal/mcf"
+	"mcf-dev/tui/internal/ui"
+)
+
+// doctorIcons maps check outcomes to ui icon names
+var doctorIcons = map[mcf.DoctorStatus]string{
+	mcf.DoctorOK:   "ok",
+	mcf.DoctorWarn: "warning",
+	mcf.DoctorFail: "error",
+}
+
+// runDoctor handles the `doctor` subcommand, diagnosing the install above the
+// working directory. The exit code is 1 when any check fails, warnings aside.
+// With --fix, fixable problems are repaired after confirmation and re-checked.
+func runDoctor(args []string) int {
+	fs, quietFlag := newFlagSet("doctor")
+	fixFlag := fs.Bool("fix", false, "Repair problems that have an automatic fix")
+	yesFlag := fs.Bool("yes", false, "Apply fixes without asking")
+	noColorFlag := fs.Bool("no-color", false, "Use ASCII icons (also set by NO_COLOR)")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	ui.SetNoColor(ui.NoColorRequested(*noColorFlag))
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	// Without a .claude directory, diagnose the working directory so every check still reports
+	mcfRoot, rootErr := mcf.FindMCFRoot(cwd)
+	if rootErr != nil {
+		mcfRoot = cwd
+	}
+
+	if !*quietFlag {
+		fmt.Printf("Checking MCF install in %s\n\n", mcfRoot)
+	}
+	checks := mcf.Diagnose(mcfRoot)
+	printDoctorChecks(checks, *quietFlag)
+
+	if *fixFlag {
+		checks = fixDoctorChecks(mcfRoot, checks, *yesFlag, *quietFlag)
+		if _, err := mcf.FindMCFRoot(cwd); err == nil {
+			rootErr = nil
+		}
+	}
+
+	switch {
+	case rootErr != nil:
+		return exitNotInitialized
+	case mcf.DoctorFailed(checks):
+		return exitFailure
+	}
+	return exitOK
+}
+
+// printDoctorChecks prints each check with its icon, and remediation for problems.
+// Quiet output lists only the problems.
+func printDoctorChecks(checks []mcf.DoctorCheck, quiet bool) {
+	for _, check := range checks {
+		if quiet && check.Status == mcf.DoctorOK {
+			continue
+		}
+		fmt.Printf("%s %s\n", ui.Icon(doctorIcons[check.Status]), check)
+		if check.Status != mcf.DoctorOK && check.Remediation != "" {
+			fmt.Printf("   %s\n", check.Remediation)
+		}
+	}
+}
+
+// fixDoctorChecks applies the confirmed automatic fixes, logs them to the audit
+// log, and returns the checks re-run afterwards
+func fixDoctorChecks(mcfRoot string, checks []mcf.DoctorCheck, yes, quiet bool) []mcf.DoctorCheck {
+	var fixable []mcf.DoctorCheck
+	for _, check := range checks {
+		if check.Fixable() {
+			fixable = append(fixable, check)
+		}
+	}
+	if len(fixable) == 0 {
+		if !quiet {
+			fmt.Println("\nNothing to fix automatically.")
+		}
+		return checks
+	}
+
+	logger, err := mcf.NewLogger(mcf.LogDir(mcfRoot), false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: fixes will not be logged: %v\n", err)
+	} else {
+		defer logger.Close()
+	}
+
+	fmt.Println()
+	input := bufio.NewReader(os.Stdin)
+	for _, check := range fixable {
+		if !yes && !confirm(input, fmt.Sprintf("Fix %s? [y/N] ", check.Name)) {
+			continue
+		}
+		err := check.Fix()
+		if logger != nil {
+			logger.LogMCFOperation("doctor fix", map[string]interface{}{
+				"check": check.Name, "detail": check.Detail, "success": err == nil,
+			})
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to fix %s: %v\n", check.Name, err)
+		}
+	}
+
+	// Re-verify rather than trusting the fixes
+	rechecked := mcf.Diagnose(mcfRoot)
+	if !quiet {
+		fmt.Println("\nAfter fixes:")
+		printDoctorChecks(rechecked, false)
+	}
+
+	var fixed, manual []string
+	for i, check := range checks {
+		switch {
+		case check.Status == mcf.DoctorOK:
+		case rechecked[i].Status == mcf.DoctorOK:
+			fixed = append(fixed, check.Name)
+		default:
+			manual = append(manual, check.Name)
+		}
+	}
+	fmt.Println()
+	if len(fixed) > 0 {
+		fmt.Printf("Fixed: %s\n", strings.Join(fixed, ", "))
+	}
+	if len(manual) > 0 {
+		fmt.Printf("Needs manual attention: %s\n", strings.Join(manual, ", "))
+	}
+	return rechecked
+}
+
+// confirm asks a yes/no question on stdin; anything but y or yes is no
+func confirm(input *bufio.Reader, prompt string) bool {
+	fmt.Print(prompt)
+	answer, _ := input.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,20 @@
+package main
+
+// Exit codes shared by all subcommands so wrapping scripts can tell outcomes apart
+const (
+	exitOK             = 0   // success
+	exitFailure        = 1   // execution failed (I/O, command error)
+	exitUsage          = 2   // invalid arguments or configuration
+	exitNotInitialized = 3   // no .claude directory found
+	exitCancelled      = 130 // cancelled by the user (Ctrl+C)
+)
+
+// exitCodesHelp documents the exit codes in usage output
+const exitCodesHelp = `Exit codes:
+  0    success
+  1    execution failure
+  2    invalid arguments or configuration
+  3    MCF not initialized (no .claude directory)
+  130  cancelled by user
+run exits with the failed command's own exit code instead of 1.
+`
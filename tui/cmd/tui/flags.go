@@ -0,0 +1,14 @@
+package main
+
+import "flag"
+
+// quietUsage documents the --quiet flag shared by every subcommand
+const quietUsage = "Suppress headers and progress messages; print only results and errors"
+
+// newFlagSet returns the flag set for a subcommand with the flags every
+// subcommand accepts already registered. The returned bool reports --quiet.
+func newFlagSet(name string) (*flag.FlagSet, *bool) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	quiet := fs.Bool("quiet", false, quietUsage)
+	return fs, quiet
+}
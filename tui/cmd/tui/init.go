@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"mcf-dev/tui/internal/mcf"
+)
+
+// runInit handles the `init` subcommand, scaffolding .claude in the current directory
+func runInit(args []string) int {
+	fs, quietFlag := newFlagSet("init")
+	forceFlag := fs.Bool("force", false, "Re-initialize even if .claude already exists")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if err := mcf.InitProject(cwd, *forceFlag); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if errors.Is(err, mcf.ErrAlreadyInitialized) {
+			return exitUsage
+		}
+		return exitFailure
+	}
+
+	if !*quietFlag {
+		fmt.Printf("Initialized MCF project in %s\n", cwd)
+		fmt.Println("Add agents to .claude/agents and commands to .claude/commands, then run mcf-tui.")
+	}
+	return exitOK
+}
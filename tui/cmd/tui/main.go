@@ -1,25 +1,45 @@
 package main
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 
 	"mcf-dev/tui/internal/app"
+	"mcf-dev/tui/internal/ui"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
+	// Dispatch subcommands before parsing TUI flags
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "config":
+			os.Exit(runConfig(os.Args[2:]))
+		case "init":
+			os.Exit(runInit(os.Args[2:]))
+		case "doctor":
+			os.Exit(runDoctor(os.Args[2:]))
+		case "run":
+			os.Exit(runRun(os.Args[2:]))
+		}
+	}
+
 	// Parse command line flags
 	debugFlag := flag.Bool("debug", false, "Enable debug logging to stdout")
 	logDirFlag := flag.String("log-dir", "", "Directory for log files (default: <mcf-root>/logs)")
+	reindexFlag := flag.Bool("reindex", false, "Rebuild the cached agent/command index at startup")
+	noColorFlag := flag.Bool("no-color", false, "Disable colors and use ASCII icons (also set by NO_COLOR)")
 	helpFlag := flag.Bool("help", false, "Show help message")
+	flag.Usage = usage
 	flag.Parse()
 
 	if *helpFlag {
 		flag.Usage()
-		os.Exit(0)
+		os.Exit(exitOK)
 	}
 
 	// Set debug mode via environment variable if flag is set
@@ -29,6 +49,10 @@ func main() {
 	if *logDirFlag != "" {
 		os.Setenv("MCF_TUI_LOG_DIR", *logDirFlag)
 	}
+	if *reindexFlag {
+		os.Setenv("MCF_TUI_REINDEX", "true")
+	}
+	ui.SetNoColor(ui.NoColorRequested(*noColorFlag))
 
 	// Initialize the TUI application
 	model := app.InitialModel()
@@ -42,6 +66,34 @@ func main() {
 
 	// Start the program (this is the correct way - Run() calls Start() internally)
 	if _, err := p.Run(); err != nil {
+		if errors.Is(err, tea.ErrProgramKilled) {
+			os.Exit(exitCancelled)
+		}
 		log.Fatal(err)
 	}
 }
+
+// usage prints the TUI flags, subcommands and exit codes
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintln(out, "Usage: mcf-tui [flags]")
+	fmt.Fprintln(out, "       mcf-tui init [--force] [--quiet]")
+	fmt.Fprintln(out, "       mcf-tui config show [--json] [--quiet]")
+	fmt.Fprintln(out, "       mcf-tui config search [--json] [--quiet] <query>")
+	fmt.Fprintln(out, "       mcf-tui config repair [--yes] [--quiet]")
+	fmt.Fprintln(out, "       mcf-tui config export [--include-secrets] [--quiet] <file>")
+	fmt.Fprintln(out, "       mcf-tui config import [--layer global|project|local] [--quiet] <file>")
+	fmt.Fprintln(out, "       mcf-tui config edit [--layer global|project|local] [--quiet]")
+	fmt.Fprintln(out, "       mcf-tui run [--json] [--output <file>] [--dry-run] [--quiet] <command> [args...]")
+	fmt.Fprintln(out, "       mcf-tui doctor [--fix [--yes]] [--no-color] [--quiet]")
+	fmt.Fprintln(out, "\nFlags:")
+	flag.PrintDefaults()
+	fmt.Fprint(out, "\n"+envHelp)
+	fmt.Fprint(out, "\n"+exitCodesHelp)
+}
+
+// envHelp documents the environment overrides for TUI config values
+var envHelp = `Environment:
+  ` + app.ConfigEnvPrefix + `<SECTION>_<KEY>  override a TUI config value, e.g. ` + app.ConfigEnvPrefix + `TUI_THEME=light for tui.theme
+                       (environment > local > project > global config > defaults)
+`
@@ -0,0 +1,509 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chdir switches the working directory for the duration of a test
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	prev, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(prev) })
+}
+
+// captureStdout runs fn and returns what it printed to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	w.Close()
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(out)
+}
+
+// captureStderr runs fn and returns what it printed to stderr
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	stderr := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = stderr }()
+
+	fn()
+	w.Close()
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(out)
+}
+
+// withStdin feeds input to prompts for the duration of a test
+func withStdin(t *testing.T, input string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stdin")
+	require.NoError(t, os.WriteFile(path, []byte(input), 0644))
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	stdin := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		f.Close()
+	})
+}
+
+// writeSettings creates .claude/settings.json in dir
+func writeSettings(t *testing.T, dir, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude", "settings.json"), []byte(content), 0644))
+}
+
+func TestConfigSearch(t *testing.T) {
+	dir := t.TempDir()
+	writeSettings(t, dir, `{"version": "1.0.0", "env": {"ANTHROPIC_MODEL": "claude-sonnet-4", "DISABLE_TELEMETRY": "1"}}`)
+	chdir(t, dir)
+
+	t.Run("should print matching keys as a table", func(t *testing.T) {
+		var code int
+		out := captureStdout(t, func() { code = runConfig([]string{"search", "model"}) })
+
+		assert.Equal(t, exitOK, code)
+		assert.Contains(t, out, "KEY")
+		assert.Regexp(t, `env\.ANTHROPIC_MODEL\s+claude-sonnet-4`, out)
+		assert.NotContains(t, out, "DISABLE_TELEMETRY")
+	})
+
+	t.Run("should print matches as JSON", func(t *testing.T) {
+		var code int
+		out := captureStdout(t, func() { code = runConfig([]string{"search", "--json", "telemetry"}) })
+
+		assert.Equal(t, exitOK, code)
+		var matches []map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(out), &matches))
+		require.Len(t, matches, 1)
+		assert.Equal(t, "env.DISABLE_TELEMETRY", matches[0]["key"])
+		assert.Equal(t, "1", matches[0]["value"])
+	})
+
+	t.Run("should print an empty JSON list when nothing matches", func(t *testing.T) {
+		out := captureStdout(t, func() { runConfig([]string{"search", "--json", "nothing"}) })
+
+		assert.JSONEq(t, "[]", out)
+	})
+
+	t.Run("should require exactly one query", func(t *testing.T) {
+		assert.Equal(t, exitUsage, runConfig([]string{"search"}))
+	})
+}
+
+func TestExitCodes(t *testing.T) {
+	t.Run("should report usage errors", func(t *testing.T) {
+		assert.Equal(t, exitUsage, runConfig(nil))
+		assert.Equal(t, exitUsage, runConfig([]string{"show", "--bogus"}))
+		assert.Equal(t, exitUsage, runInit([]string{"--bogus"}))
+	})
+
+	t.Run("should report a missing .claude as not initialized", func(t *testing.T) {
+		chdir(t, t.TempDir())
+
+		assert.Equal(t, exitNotInitialized, runConfig([]string{"show", "--quiet"}))
+	})
+
+	t.Run("should report invalid settings as a validation error", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude", "settings.json"), []byte("{"), 0644))
+		chdir(t, dir)
+
+		assert.Equal(t, exitUsage, runConfig([]string{"show", "--quiet"}))
+	})
+
+	t.Run("should succeed for init then refuse to re-init", func(t *testing.T) {
+		chdir(t, t.TempDir())
+
+		assert.Equal(t, exitOK, runInit([]string{"--quiet"}))
+		assert.Equal(t, exitOK, runConfig([]string{"show", "--quiet"}))
+		assert.Equal(t, exitUsage, runInit([]string{"--quiet"}))
+		assert.Equal(t, exitOK, runInit([]string{"--quiet", "--force"}))
+	})
+}
+
+func TestQuiet(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("MCF_TUI_LOG_DIR", t.TempDir())
+	// Keep a real claude CLI out of doctor's auth check
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "true")
+	dir := t.TempDir()
+	chdir(t, dir)
+	require.Equal(t, exitOK, runInit([]string{"--quiet"}))
+	path := filepath.Join(dir, ".claude", "commands", "ci", "greet.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte("command: echo hello\n# Runs in bash"), 0644))
+	bundle := filepath.Join(t.TempDir(), "team.yaml")
+
+	subcommands := []struct {
+		name string
+		run  func([]string) int
+		args []string
+	}{
+		{"init", runInit, []string{"--quiet", "--force"}},
+		{"doctor", runDoctor, []string{"--quiet"}},
+		{"run", runRun, []string{"--quiet", "ci:greet"}},
+		{"config show", runConfig, []string{"show", "--quiet"}},
+		{"config search", runConfig, []string{"search", "--quiet", "model"}},
+		{"config repair", runConfig, []string{"repair", "--quiet"}},
+		{"config export", runConfig, []string{"export", "--quiet", bundle}},
+		{"config import", runConfig, []string{"import", "--quiet", bundle}},
+		{"config edit", runConfig, []string{"edit", "--quiet"}},
+	}
+	for _, sc := range subcommands {
+		t.Run("should accept --quiet for "+sc.name, func(t *testing.T) {
+			var code int
+			captureStdout(t, func() { code = sc.run(sc.args) })
+
+			assert.NotEqual(t, exitUsage, code)
+		})
+	}
+
+	t.Run("should drop headers and progress messages", func(t *testing.T) {
+		out := captureStdout(t, func() { runConfig([]string{"search", "--quiet", "model"}) })
+		assert.NotContains(t, out, "KEY")
+		assert.Contains(t, out, "env.ANTHROPIC_MODEL")
+
+		out = captureStdout(t, func() { runDoctor([]string{"--quiet", "--no-color"}) })
+		assert.NotContains(t, out, "Checking MCF install")
+		assert.NotContains(t, out, "+ settings.json", "Quiet doctor lists only problems")
+		assert.Contains(t, out, "x Claude CLI")
+
+		out = captureStdout(t, func() { runConfig([]string{"export", "--quiet", bundle}) })
+		assert.Empty(t, out)
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Setenv("MCF_TUI_LOG_DIR", t.TempDir())
+	dir := t.TempDir()
+	writeSettings(t, dir, `{"version": "1.0.0"}`)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude", "agents"), 0755))
+	commands := map[string]string{
+		"greet.md": "command: echo hello\n# Runs in bash",
+		"fail.md":  "command: echo oops >&2; exit 3\n# Runs in bash",
+	}
+	for name, content := range commands {
+		path := filepath.Join(dir, ".claude", "commands", "ci", name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	chdir(t, dir)
+
+	t.Run("should print the result as JSON", func(t *testing.T) {
+		var code int
+		out := captureStdout(t, func() { code = runRun([]string{"--json", "ci:greet"}) })
+
+		assert.Equal(t, exitOK, code)
+		var report map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(out), &report))
+		assert.Equal(t, "ci:greet", report["command"])
+		assert.Equal(t, []interface{}{}, report["args"])
+		assert.Equal(t, true, report["success"])
+		assert.Equal(t, float64(0), report["exitCode"])
+		assert.Equal(t, "hello\n", report["stdout"])
+		assert.Equal(t, "", report["stderr"])
+		assert.Contains(t, report, "durationMs")
+		assert.Contains(t, report, "timestamp")
+	})
+
+	t.Run("should exit with the operation's code and write the report file on failure", func(t *testing.T) {
+		reportPath := filepath.Join(t.TempDir(), "result.json")
+
+		var code int
+		captureStdout(t, func() { code = runRun([]string{"--output", reportPath, "ci:fail"}) })
+
+		assert.Equal(t, 3, code, "The operation exited 3")
+		data, err := os.ReadFile(reportPath)
+		require.NoError(t, err)
+		var report map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &report))
+		assert.Equal(t, false, report["success"])
+		assert.Equal(t, float64(3), report["exitCode"])
+		assert.Equal(t, "oops\n", report["stderr"])
+	})
+
+	t.Run("should print what would run without running it", func(t *testing.T) {
+		var code int
+		out := captureStdout(t, func() { code = runRun([]string{"--dry-run", "ci:fail"}) })
+
+		assert.Equal(t, exitOK, code)
+		assert.Contains(t, out, "Command: bash -c 'echo oops >&2; exit 3'")
+		assert.Contains(t, out, "Dir: ")
+	})
+
+	t.Run("should warn about incomplete settings before running", func(t *testing.T) {
+		var code int
+		stderr := captureStderr(t, func() {
+			captureStdout(t, func() { code = runRun([]string{"ci:greet"}) })
+		})
+
+		assert.Equal(t, exitOK, code)
+		assert.Contains(t, stderr, "Warning: settings.json env.ANTHROPIC_MODEL is missing")
+		assert.Contains(t, stderr, "config repair")
+	})
+
+	t.Run("should reject unknown commands and missing arguments", func(t *testing.T) {
+		assert.Equal(t, exitUsage, runRun([]string{"ci:missing"}))
+		assert.Equal(t, exitUsage, runRun(nil))
+	})
+}
+
+func TestDoctor(t *testing.T) {
+	// Keep a real claude CLI out of the auth check
+	t.Setenv("PATH", t.TempDir())
+
+	t.Run("should pass a freshly initialized project", func(t *testing.T) {
+		chdir(t, t.TempDir())
+		bin := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(bin, "claude"), []byte("#!/bin/sh\n"), 0755))
+		t.Setenv("PATH", bin)
+		require.Equal(t, exitOK, runInit([]string{"--quiet"}))
+
+		var code int
+		out := captureStdout(t, func() { code = runDoctor([]string{"--no-color"}) })
+
+		assert.Equal(t, exitOK, code, "Empty operation directories are only warnings")
+		assert.Contains(t, out, "+ settings.json: valid")
+		assert.Contains(t, out, "! .claude structure: no operations in")
+		assert.Contains(t, out, "+ Claude CLI: "+filepath.Join(bin, "claude"))
+		assert.Contains(t, out, "+ Claude auth: authenticated")
+	})
+
+	t.Run("should fail with remediation for broken settings", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSettings(t, dir, "{")
+		chdir(t, dir)
+
+		var code int
+		out := captureStdout(t, func() { code = runDoctor([]string{"--no-color"}) })
+
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, out, "x settings.json:")
+		assert.Contains(t, out, "Fix the JSON syntax")
+	})
+
+	t.Run("should report a missing .claude as not initialized", func(t *testing.T) {
+		chdir(t, t.TempDir())
+
+		var code int
+		out := captureStdout(t, func() { code = runDoctor(nil) })
+
+		assert.Equal(t, exitNotInitialized, code)
+		assert.Contains(t, out, "mcf-tui init")
+	})
+}
+
+func TestDoctorFix(t *testing.T) {
+	// Keep a real claude CLI out of the auth check
+	t.Setenv("PATH", t.TempDir())
+
+	t.Run("should repair and re-verify with --yes", func(t *testing.T) {
+		t.Setenv("MCF_TUI_LOG_DIR", t.TempDir())
+		bin := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(bin, "claude"), []byte("#!/bin/sh\n"), 0755))
+		t.Setenv("PATH", bin)
+		dir := t.TempDir()
+		writeSettings(t, dir, `{"version": "1.0.0"}`)
+		chdir(t, dir)
+
+		var code int
+		out := captureStdout(t, func() { code = runDoctor([]string{"--fix", "--yes", "--no-color"}) })
+
+		assert.Equal(t, exitOK, code)
+		assert.Contains(t, out, "After fixes:")
+		assert.Regexp(t, `Fixed: .*settings\.json`, out)
+		assert.Contains(t, out, "Needs manual attention: .claude structure", "Empty operation directories need real operations")
+		settings, err := os.ReadFile(filepath.Join(dir, ".claude", "settings.json"))
+		require.NoError(t, err)
+		assert.Contains(t, string(settings), "ANTHROPIC_MODEL")
+		assert.DirExists(t, filepath.Join(dir, ".claude", "commands"))
+	})
+
+	t.Run("should skip fixes the user declines", func(t *testing.T) {
+		t.Setenv("MCF_TUI_LOG_DIR", t.TempDir())
+		dir := t.TempDir()
+		writeSettings(t, dir, `{"version": "1.0.0"}`)
+		chdir(t, dir)
+		withStdin(t, "n\nn\n")
+
+		var code int
+		out := captureStdout(t, func() { code = runDoctor([]string{"--fix", "--no-color"}) })
+
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, out, "Fix settings.json? [y/N]")
+		assert.NoDirExists(t, filepath.Join(dir, ".claude", "commands"))
+		assert.NotContains(t, out, "Fixed:")
+	})
+}
+
+func TestConfigRepairRestore(t *testing.T) {
+	// corruptLayer breaks the project config and leaves one backup of its last good version
+	corruptLayer := func(t *testing.T) string {
+		t.Helper()
+		t.Setenv("HOME", t.TempDir())
+		dir := t.TempDir()
+		writeSettings(t, dir, `{"env": {"ANTHROPIC_MODEL": "m"}, "outputStyle": "default", "hooks": {}}`)
+		chdir(t, dir)
+		path := filepath.Join(dir, ".claude", "mcf-tui.json")
+		require.NoError(t, os.WriteFile(path+".20260101-000000.000000000.bak", []byte(`{"tui": {"theme": "light"}}`), 0600))
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+		return path
+	}
+
+	t.Run("should point corrupt config at config repair", func(t *testing.T) {
+		corruptLayer(t)
+
+		var code int
+		stderr := captureStderr(t, func() { code = runConfig([]string{"export", filepath.Join(t.TempDir(), "out.yaml")}) })
+
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "project config:")
+		assert.Contains(t, stderr, "config repair")
+	})
+
+	t.Run("should restore the latest backup with --yes", func(t *testing.T) {
+		path := corruptLayer(t)
+
+		var code int
+		out := captureStdout(t, func() { code = runConfig([]string{"repair", "--yes"}) })
+
+		assert.Equal(t, exitOK, code)
+		assert.Contains(t, out, "restored project config from")
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"tui": {"theme": "light"}}`, string(data))
+	})
+
+	t.Run("should keep the corrupt file when the user declines", func(t *testing.T) {
+		path := corruptLayer(t)
+		withStdin(t, "n\n")
+
+		var code int
+		out := captureStdout(t, func() { code = runConfig([]string{"repair"}) })
+
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, out, "The project config is corrupt. Restore it from")
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "{", string(data))
+	})
+}
+
+func TestConfigEdit(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("VISUAL", "")
+	t.Setenv("TMPDIR", t.TempDir()) // rejected edits are left there
+	dir := t.TempDir()
+	writeSettings(t, dir, `{"version": "1.0.0"}`)
+	chdir(t, dir)
+	globalPath := filepath.Join(home, ".config", "mcf-tui", "config.json")
+
+	// useEditor makes $EDITOR a script that runs body on the file in $1
+	useEditor := func(t *testing.T, body string) {
+		t.Helper()
+		script := filepath.Join(t.TempDir(), "editor")
+		require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\n"+body+"\n"), 0755))
+		t.Setenv("EDITOR", script)
+	}
+
+	t.Run("should save a valid edit", func(t *testing.T) {
+		useEditor(t, `sed -i 's/"dark"/"light"/' "$1"`)
+
+		var code int
+		out := captureStdout(t, func() { code = runConfig([]string{"edit"}) })
+
+		assert.Equal(t, exitOK, code)
+		assert.Contains(t, out, "saved global config to "+globalPath)
+		data, err := os.ReadFile(globalPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"theme": "light"`)
+	})
+
+	t.Run("should reject an invalid edit and keep it for the user", func(t *testing.T) {
+		before, err := os.ReadFile(globalPath)
+		require.NoError(t, err)
+		useEditor(t, `echo '{"mcf": {"port": 70000}}' > "$1"`)
+
+		var code int
+		stderr := captureStderr(t, func() { code = runConfig([]string{"edit"}) })
+
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "invalid configuration")
+		assert.Contains(t, stderr, "mcf.port must be between 1 and 65535")
+		assert.Contains(t, stderr, "your edit is saved in")
+		after, err := os.ReadFile(globalPath)
+		require.NoError(t, err)
+		assert.Equal(t, string(before), string(after))
+	})
+
+	t.Run("should leave the file alone when nothing changed", func(t *testing.T) {
+		useEditor(t, "true")
+
+		var code int
+		out := captureStdout(t, func() { code = runConfig([]string{"edit", "--layer", "local"}) })
+
+		assert.Equal(t, exitOK, code)
+		assert.Contains(t, out, "no changes")
+		assert.NoFileExists(t, filepath.Join(dir, ".claude", "mcf-tui.local.json"))
+	})
+}
+
+func TestConfigBundle(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	writeSettings(t, dir, `{"version": "1.0.0"}`)
+	chdir(t, dir)
+	bundle := filepath.Join(t.TempDir(), "team.yaml")
+
+	t.Run("should export then import a bundle", func(t *testing.T) {
+		var code int
+		out := captureStdout(t, func() { code = runConfig([]string{"export", bundle}) })
+
+		require.Equal(t, exitOK, code)
+		assert.Contains(t, out, "secrets were redacted")
+		assert.FileExists(t, bundle)
+
+		out = captureStdout(t, func() { code = runConfig([]string{"import", "--layer", "local", bundle}) })
+
+		assert.Equal(t, exitOK, code)
+		assert.Contains(t, out, "into the local config")
+		assert.FileExists(t, filepath.Join(dir, ".claude", "mcf-tui.local.json"))
+	})
+
+	t.Run("should report bad input as usage errors", func(t *testing.T) {
+		assert.Equal(t, exitUsage, runConfig([]string{"export"}))
+		assert.Equal(t, exitUsage, runConfig([]string{"import", "--layer", "team", bundle}))
+
+		future := filepath.Join(t.TempDir(), "future.yaml")
+		require.NoError(t, os.WriteFile(future, []byte("version: 99\n"), 0600))
+		assert.Equal(t, exitUsage, runConfig([]string{"import", future}))
+	})
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"mcf-dev/tui/internal/mcf"
+)
+
+const runUsage = `usage: mcf-tui run [--json] [--output <file>] [--dry-run] [--quiet] <command> [args...]`
+
+// runRun handles the headless `run` subcommand, executing one MCF command.
+// The exit code is 0 when the command succeeds and the command's own exit code
+// when it fails; 1 is kept for failures inside the runner.
+func runRun(args []string) int {
+	// run prints only the command's own output, so --quiet changes nothing here
+	fs, _ := newFlagSet("run")
+	jsonFlag := fs.Bool("json", false, "Print the result as JSON")
+	outputFlag := fs.String("output", "", "Also write the JSON result to this file")
+	dryRunFlag := fs.Bool("dry-run", false, "Print what would run instead of running it")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, runUsage)
+		return exitUsage
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	mcfRoot, err := mcf.FindMCFRoot(cwd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitNotInitialized
+	}
+	adapter, err := mcf.NewMCFAdapter(mcfRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitNotInitialized
+	}
+
+	name := fs.Arg(0)
+	if _, ok := adapter.GetCommands()[name]; !ok {
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q\n", name)
+		return exitUsage
+	}
+
+	if *dryRunFlag {
+		invocation, err := adapter.PreviewCommand(name, fs.Args()[1:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+		fmt.Println(invocation)
+		return exitOK
+	}
+
+	// Incomplete settings make Claude calls fail cryptically; say why up front
+	for _, issue := range adapter.SettingsIssues() {
+		fmt.Fprintf(os.Stderr, "Warning: settings.json %s (run `mcf-tui config repair`)\n", issue)
+	}
+
+	started := time.Now()
+	result, err := adapter.ExecuteCommand(name, fs.Args()[1:])
+	if err != nil {
+		result = &mcf.CommandResult{Error: err.Error(), Code: exitFailure}
+	}
+	report := mcf.NewExecutionReport(name, fs.Args()[1:], result, started, time.Since(started))
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	if *outputFlag != "" {
+		if err := os.WriteFile(*outputFlag, append(data, '\n'), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+	}
+
+	if *jsonFlag {
+		fmt.Println(string(data))
+	} else {
+		fmt.Print(result.Output)
+		if result.Error != "" {
+			fmt.Fprintln(os.Stderr, result.Error)
+		}
+	}
+
+	if !result.Success {
+		return operationExitCode(result)
+	}
+	return exitOK
+}
+
+// operationExitCode passes a failed operation's own exit code through to the
+// caller, falling back to exitFailure when the operation has none (it could not
+// start, was killed, or the runner itself failed)
+func operationExitCode(result *mcf.CommandResult) int {
+	if result.Code > 0 && result.Code < 256 {
+		return result.Code
+	}
+	return exitFailure
+}
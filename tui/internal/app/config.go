@@ -0,0 +1,142 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"mcf-dev/tui/internal/config"
+	"mcf-dev/tui/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ConfigEnvPrefix prefixes the environment variables that override TUI config
+// values: MCF_TUI_THEME sets tui.theme and MCF_TUI_REFRESH_RATE sets
+// tui.refresh_rate. The environment beats the config files, which beat the defaults.
+const ConfigEnvPrefix = "MCF_"
+
+// defaultTickInterval paces background refreshes when no refresh rate is configured
+const defaultTickInterval = 5 * time.Second
+
+// minTickInterval keeps a tiny tui.refresh_rate from busy-looping the UI
+const minTickInterval = 100 * time.Millisecond
+
+// loadTUIConfig reads the layered TUI configuration for mcfRoot and applies
+// environment overrides right after, so everything reads the same values.
+// Problems are logged; nil is returned when the files cannot be loaded.
+func loadTUIConfig(mcfRoot string, logViewer *ui.LogViewer) *config.LayeredConfig {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		logConfigProblem(logViewer, err)
+		return nil
+	}
+
+	cfg := config.NewLayeredConfig(config.DefaultLayerPaths(home, mcfRoot), nil)
+	if err := cfg.Load(); err != nil {
+		logConfigProblem(logViewer, err)
+		return nil
+	}
+	if err := cfg.ApplyEnvOverrides(ConfigEnvPrefix); err != nil {
+		// The overrides that did coerce still apply
+		logConfigProblem(logViewer, err)
+	}
+	return cfg
+}
+
+// logConfigProblem reports a config problem in the log view; the defaults stay in effect
+func logConfigProblem(logViewer *ui.LogViewer, err error) {
+	message := "Config: " + err.Error()
+	if errors.Is(err, config.ErrCorruptConfig) {
+		message += " (run `mcf-tui config repair`)"
+	}
+	logViewer.AddLog(ui.LogEntry{
+		Timestamp: time.Now(),
+		Level:     "WARN",
+		Component: "config",
+		Message:   message,
+	})
+}
+
+// applyConfig restyles the UI from tui.theme and sets the refresh interval from
+// tui.refresh_rate. The theme is updated in place, since every component shares it.
+func (m *MCFModel) applyConfig() {
+	m.tickInterval = defaultTickInterval
+	if m.config == nil {
+		return
+	}
+
+	if name, _, ok := m.config.Get("tui.theme"); ok {
+		s, _ := name.(string)
+		theme, err := ui.NewNamedTheme(s)
+		if err != nil {
+			logConfigProblem(m.logViewer, err)
+		} else {
+			*m.theme = *theme
+		}
+	}
+
+	if rate, _, ok := m.config.Get("tui.refresh_rate"); ok {
+		switch ms := rate.(type) {
+		case int:
+			m.tickInterval = time.Duration(ms) * time.Millisecond
+		case float64:
+			m.tickInterval = time.Duration(ms) * time.Millisecond
+		}
+		if m.tickInterval < minTickInterval {
+			m.tickInterval = minTickInterval
+		}
+	}
+}
+
+// configReloadedMsg reports that a config file changed on disk
+type configReloadedMsg struct {
+	changes <-chan struct{}
+}
+
+// configWatchFailedMsg reports that the config files could not be watched
+type configWatchFailedMsg struct {
+	err error
+}
+
+// watchConfigCmd starts watching the config files for the life of the program
+// and waits for the first change
+func watchConfigCmd(cfg *config.LayeredConfig) tea.Cmd {
+	if cfg == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		changes, err := cfg.Watch(context.Background())
+		if err != nil {
+			return configWatchFailedMsg{err: err}
+		}
+		return awaitConfigChange(changes)()
+	}
+}
+
+// awaitConfigChange waits for the next config change; the watch ends with the channel
+func awaitConfigChange(changes <-chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		if _, ok := <-changes; !ok {
+			return nil
+		}
+		return configReloadedMsg{changes: changes}
+	}
+}
+
+// reloadConfig re-reads the config files after a change and re-applies the theme
+// and refresh interval. A file that fails to load leaves the current settings.
+func (m *MCFModel) reloadConfig() {
+	if err := m.config.Load(); err != nil {
+		logConfigProblem(m.logViewer, err)
+		return
+	}
+	m.applyConfig()
+	m.logViewer.AddLog(ui.LogEntry{
+		Timestamp: time.Now(),
+		Level:     "INFO",
+		Component: "config",
+		Message:   "Configuration reloaded",
+	})
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mcf-dev/tui/internal/ui"
+)
+
+func TestMain(m *testing.M) {
+	// InitialModel creates the global config file; keep it out of the real home
+	home, err := os.MkdirTemp("", "mcf-tui-home-*")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("HOME", home)
+	code := m.Run()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
+
+func TestInitialModel_Config(t *testing.T) {
+	t.Run("should apply environment overrides at startup", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		t.Setenv("MCF_TUI_THEME", "light")
+		t.Setenv("MCF_TUI_REFRESH_RATE", "250")
+
+		model := InitialModel()
+
+		light, err := ui.NewNamedTheme("light")
+		require.NoError(t, err)
+		assert.Equal(t, light.Body.GetForeground(), model.theme.Body.GetForeground())
+		assert.Equal(t, 250*time.Millisecond, model.tickInterval)
+	})
+
+	t.Run("should log overrides that do not coerce and keep the rest", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		t.Setenv("MCF_TUI_THEME", "")
+		t.Setenv("MCF_TUI_REFRESH_RATE", "fast")
+
+		model := InitialModel()
+
+		assert.Contains(t, model.logViewer.Render(300), "MCF_TUI_REFRESH_RATE")
+		assert.Equal(t, time.Second, model.tickInterval, "Default tui.refresh_rate should apply")
+	})
+
+	t.Run("should keep the defaults when the config is corrupt", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		path := filepath.Join(home, ".config", "mcf-tui", "config.json")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+
+		model := InitialModel()
+
+		assert.Nil(t, model.config)
+		assert.Contains(t, model.logViewer.Render(300), "config repair")
+		assert.Equal(t, defaultTickInterval, model.tickInterval)
+	})
+}
+
+func TestMCFModelUpdate_ConfigReload(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	model := InitialModel()
+	require.NotNil(t, model.config)
+	assert.Equal(t, time.Second, model.tickInterval)
+
+	msgs := make(chan tea.Msg, 1)
+	go func() { msgs <- watchConfigCmd(model.config)() }()
+	time.Sleep(100 * time.Millisecond) // let the watch take its first look
+
+	path := filepath.Join(home, ".config", "mcf-tui", "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"tui": {"theme": "light", "refresh_rate": 2000}}`), 0600))
+
+	var msg tea.Msg
+	select {
+	case msg = <-msgs:
+	case <-time.After(2 * time.Second):
+		t.Fatal("No reload after the config changed")
+	}
+	require.IsType(t, configReloadedMsg{}, msg)
+
+	newModel, cmd := model.Update(msg)
+	model = newModel.(MCFModel)
+
+	assert.NotNil(t, cmd, "Should keep waiting for the next change")
+	assert.Equal(t, 2*time.Second, model.tickInterval)
+	light, err := ui.NewNamedTheme("light")
+	require.NoError(t, err)
+	assert.Equal(t, light.Body.GetForeground(), model.theme.Body.GetForeground())
+	assert.Contains(t, model.logViewer.Render(300), "Configuration reloaded")
+}
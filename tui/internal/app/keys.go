@@ -0,0 +1,103 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap holds the global keybindings; view-local keys stay with their views
+type KeyMap struct {
+	Quit       key.Binding
+	Help       key.Binding
+	NextView   key.Binding
+	PrevView   key.Binding
+	CommandBar key.Binding
+	Back       key.Binding
+	Refresh    key.Binding
+}
+
+// DefaultKeyMap returns the built-in global keybindings
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Quit:       key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+		Help:       key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+		NextView:   key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next view")),
+		PrevView:   key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "previous view")),
+		CommandBar: key.NewBinding(key.WithKeys(":"), key.WithHelp(":", "command bar")),
+		Back:       key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+		Refresh:    key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+	}
+}
+
+// actions maps dot-notation action names to their bindings
+func (k *KeyMap) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"global.quit":        &k.Quit,
+		"global.help":        &k.Help,
+		"global.next_view":   &k.NextView,
+		"global.prev_view":   &k.PrevView,
+		"global.command_bar": &k.CommandBar,
+		"global.back":        &k.Back,
+		"global.refresh":     &k.Refresh,
+	}
+}
+
+// LoadKeyMap applies overrides (action -> comma-separated keys) on top of the defaults.
+// Unknown actions and conflicting keys are reported; on any error the defaults are returned.
+func LoadKeyMap(overrides map[string]interface{}) (KeyMap, []error) {
+	keys := DefaultKeyMap()
+	actions := keys.actions()
+	var errs []error
+
+	for action, value := range overrides {
+		binding, ok := actions[action]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown keybinding action %q", action))
+			continue
+		}
+
+		spec, ok := value.(string)
+		if !ok || strings.TrimSpace(spec) == "" {
+			errs = append(errs, fmt.Errorf("keybinding %q must be a non-empty string", action))
+			continue
+		}
+
+		var bound []string
+		for _, k := range strings.Split(spec, ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				bound = append(bound, k)
+			}
+		}
+		binding.SetKeys(bound...)
+		binding.SetHelp(bound[0], binding.Help().Desc)
+	}
+
+	errs = append(errs, keys.conflicts()...)
+	if len(errs) > 0 {
+		return DefaultKeyMap(), errs
+	}
+	return keys, nil
+}
+
+// conflicts reports keys bound to more than one action
+func (k *KeyMap) conflicts() []error {
+	owners := map[string][]string{}
+	for action, binding := range k.actions() {
+		for _, bound := range binding.Keys() {
+			owners[bound] = append(owners[bound], action)
+		}
+	}
+
+	var errs []error
+	for bound, actions := range owners {
+		if len(actions) > 1 {
+			sort.Strings(actions)
+			errs = append(errs, fmt.Errorf("key %q is bound to %s", bound, strings.Join(actions, ", ")))
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errs
+}
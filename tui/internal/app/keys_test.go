@@ -0,0 +1,46 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mcf-dev/tui/internal/ui"
+)
+
+func TestLoadKeyMap(t *testing.T) {
+	t.Run("should trigger the remapped action", func(t *testing.T) {
+		keys, errs := LoadKeyMap(map[string]interface{}{"global.quit": "x, ctrl+q"})
+		require.Empty(t, errs)
+
+		model := InitialModel()
+		model.ready = true
+		model.keys = keys
+
+		_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+		require.NotNil(t, cmd)
+		assert.Equal(t, tea.Quit(), cmd(), "Remapped key should quit")
+
+		newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+		assert.Equal(t, ui.DashboardView, newModel.(MCFModel).navigation.GetCurrentView(), "Old key should no longer quit")
+	})
+
+	t.Run("should fall back to defaults on conflicts", func(t *testing.T) {
+		keys, errs := LoadKeyMap(map[string]interface{}{"global.help": "r"})
+
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Error(), `key "r" is bound to global.help, global.refresh`)
+		assert.Equal(t, DefaultKeyMap().Help.Keys(), keys.Help.Keys())
+	})
+
+	t.Run("should reject unknown actions and bad values", func(t *testing.T) {
+		_, errs := LoadKeyMap(map[string]interface{}{
+			"global.fly":  "f",
+			"global.quit": 42,
+		})
+
+		assert.Len(t, errs, 2)
+	})
+}
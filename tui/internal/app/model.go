@@ -4,15 +4,40 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"mcf-dev/tui/internal/config"
 	"mcf-dev/tui/internal/mcf"
 	"mcf-dev/tui/internal/ui"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// outputMode selects how command output is shown in the commands view
+type outputMode int
+
+const (
+	outputRaw outputMode = iota
+	outputPretty
+	outputCollapsed
+)
+
+func (o outputMode) String() string {
+	switch o {
+	case outputPretty:
+		return "pretty JSON"
+	case outputCollapsed:
+		return "collapsed JSON"
+	}
+	return "raw"
+}
+
+// lastOutputLines caps how much of the last output the details panel shows
+const lastOutputLines = 15
+
 // Application model
 type MCFModel struct {
 	// Core application state
@@ -21,9 +46,11 @@ type MCFModel struct {
 	height int
 
 	// MCF integration
-	mcfAdapter *mcf.MCFAdapter
+	mcfAdapter    *mcf.MCFAdapter
+	installStatus mcf.InstallationStatus
 
 	// UI components
+	keys         KeyMap
 	theme        *ui.Theme
 	navigation   *ui.Navigation
 	dashboard    *ui.Dashboard
@@ -33,7 +60,41 @@ type MCFModel struct {
 	commandInput *ui.CommandInput
 
 	// View state
-	showHelp bool
+	showHelp         bool
+	showSettingsFile bool
+	settingsViewer   *ui.TextViewer
+
+	// Bulk execution state for the commands view
+	continueOnError bool
+	playbookResults []mcf.StepResult
+	playbook        *playbookRun // playbook running in the background, nil when idle
+	separateStreams bool         // log stdout and stderr separately instead of combined
+
+	// Output of the last command run from the commands view
+	lastResult     *mcf.CommandResult
+	lastResultName string
+	outputMode     outputMode
+
+	// Command running in the background and its most recent output lines
+	running    *commandRun
+	liveOutput []string
+	spinner    spinner.Model
+
+	// Past runs, shown in place of the command list when showHistory is set
+	showHistory bool
+	historyList *ui.InteractiveList
+
+	// What the selected command would run, shown until the selection runs or changes
+	preview     *mcf.Invocation
+	previewName string
+
+	// Dashboard health polling
+	health healthPoller
+
+	// Layered TUI configuration, nil when it could not be loaded, and the
+	// background refresh interval it sets
+	config       *config.LayeredConfig
+	tickInterval time.Duration
 
 	// Performance tracking
 	lastInteractionTime int64
@@ -46,6 +107,7 @@ func InitialModel() MCFModel {
 
 	// Initialize MCF adapter
 	mcfRoot := findMCFRoot()
+	installStatus := mcf.CheckInstallation(mcfRoot)
 	mcfAdapter, err := mcf.NewMCFAdapter(mcfRoot)
 	if err != nil {
 		// Fallback to mock data if MCF adapter fails
@@ -55,23 +117,42 @@ func InitialModel() MCFModel {
 	// Initialize components
 	agentsList := ui.NewInteractiveList(theme, "Agents", 20)
 	commandsList := ui.NewInteractiveList(theme, "Command History", 20)
+	historyList := ui.NewInteractiveList(theme, "Run History", 20)
+	historyList.SetFocus(true)
 	logViewer := ui.NewLogViewer(theme, 20)
+	settingsViewer := ui.NewTextViewer(theme, 80, 20)
 	commandInput := ui.NewCommandInput(theme)
 
 	// Setup initial data (will use real MCF data if adapter is available)
 	setupInitialData(agentsList, commandsList, logViewer, mcfAdapter)
+	keys := loadKeyBindings(mcfRoot, logViewer)
+	reportSettingsIssues(mcfAdapter, logViewer)
+	cfg := loadTUIConfig(mcfRoot, logViewer)
+
+	var healthCheck func() error
+	if mcfAdapter != nil {
+		healthCheck = mcfAdapter.CheckHealth
+	}
 
 	model := MCFModel{
-		mcfAdapter:   mcfAdapter,
-		theme:        theme,
-		navigation:   navigation,
-		dashboard:    dashboard,
-		agentsList:   agentsList,
-		commandsList: commandsList,
-		logViewer:    logViewer,
-		commandInput: commandInput,
-		showHelp:     false,
+		mcfAdapter:     mcfAdapter,
+		health:         newHealthPoller(loadHealthInterval(mcfRoot), healthCheck),
+		installStatus:  installStatus,
+		keys:           keys,
+		theme:          theme,
+		navigation:     navigation,
+		dashboard:      dashboard,
+		agentsList:     agentsList,
+		commandsList:   commandsList,
+		historyList:    historyList,
+		logViewer:      logViewer,
+		settingsViewer: settingsViewer,
+		commandInput:   commandInput,
+		spinner:        spinner.New(spinner.WithSpinner(spinner.Line)),
+		showHelp:       false,
+		config:         cfg,
 	}
+	model.applyConfig()
 
 	// Initialize dashboard with real MCF data
 	if mcfAdapter != nil {
@@ -81,6 +162,46 @@ func InitialModel() MCFModel {
 	return model
 }
 
+// loadKeyBindings reads tui.keybindings from settings.json, falling back to defaults on error
+func loadKeyBindings(mcfRoot string, logViewer *ui.LogViewer) KeyMap {
+	settings, err := mcf.LoadRawSettings(mcfRoot)
+	if err != nil {
+		return DefaultKeyMap()
+	}
+
+	tuiSettings, _ := settings["tui"].(map[string]interface{})
+	overrides, _ := tuiSettings["keybindings"].(map[string]interface{})
+	if len(overrides) == 0 {
+		return DefaultKeyMap()
+	}
+
+	keys, errs := LoadKeyMap(overrides)
+	for _, err := range errs {
+		logViewer.AddLog(ui.LogEntry{
+			Timestamp: time.Now(),
+			Level:     "WARN",
+			Component: "keybindings",
+			Message:   err.Error() + " (using default keybindings)",
+		})
+	}
+	return keys
+}
+
+// reportSettingsIssues logs required settings.json keys that are missing or invalid
+func reportSettingsIssues(mcfAdapter *mcf.MCFAdapter, logViewer *ui.LogViewer) {
+	if mcfAdapter == nil {
+		return
+	}
+	for _, issue := range mcfAdapter.SettingsIssues() {
+		logViewer.AddLog(ui.LogEntry{
+			Timestamp: time.Now(),
+			Level:     "WARN",
+			Component: "settings",
+			Message:   issue.String() + " (run `mcf-tui config repair`)",
+		})
+	}
+}
+
 func setupInitialData(agentsList *ui.InteractiveList, commandsList *ui.InteractiveList, logViewer *ui.LogViewer, mcfAdapter *mcf.MCFAdapter) {
 	if mcfAdapter != nil {
 		// Use real MCF data
@@ -115,6 +236,7 @@ func setupRealData(agentsList *ui.InteractiveList, commandsList *ui.InteractiveL
 				Title:       cmd.Name,
 				Status:      "available",
 				Description: cmd.Description,
+				Metadata:    map[string]interface{}{"category": cmd.Category},
 			})
 		}
 	}
@@ -183,19 +305,8 @@ func findMCFRoot() string {
 	}
 
 	// Walk up the directory tree looking for .claude directory
-	current := cwd
-	for {
-		claudeDir := filepath.Join(current, ".claude")
-		if _, err := os.Stat(claudeDir); err == nil {
-			return current
-		}
-
-		parent := filepath.Dir(current)
-		if parent == current {
-			// Reached root directory
-			break
-		}
-		current = parent
+	if root, err := mcf.FindMCFRoot(cwd); err == nil {
+		return root
 	}
 
 	// Fallback to known path
@@ -208,38 +319,7 @@ func updateDashboardWithRealData(model *MCFModel) {
 		return
 	}
 
-	// Get real system info
-	version := model.mcfAdapter.GetVersion()
-	if version == "" || version == "unknown" {
-		version = "MCF Development"
-	}
-
-	serenaStatus := model.mcfAdapter.GetSerenaStatus()
-
-	// Get real agent data
-	agents := model.mcfAdapter.GetAgents()
-	activeCount := 0
-	agentStatuses := []ui.AgentStatus{}
-
-	for _, agent := range agents {
-		if agent.Status == "active" {
-			activeCount++
-		}
-
-		// Convert MCF agent to dashboard agent status
-		agentStatus := ui.AgentStatus{
-			Name:        agent.Name,
-			Status:      agent.Status,
-			LastSeen:    agent.LastActive,
-			TasksActive: 0, // TODO: Get real task data
-			TasksTotal:  0,
-		}
-		agentStatuses = append(agentStatuses, agentStatus)
-	}
-
-	// Update dashboard with real data
-	model.dashboard.SetSystemHealth(version, serenaStatus, activeCount, len(agents))
-	model.dashboard.SetAgentStatuses(agentStatuses)
+	refreshSystemHealth(model)
 
 	// Get real command history from discovered Claude commands
 	commands := model.mcfAdapter.GetCommands()
@@ -280,24 +360,76 @@ func updateDashboardWithRealData(model *MCFModel) {
 	model.dashboard.SetCommandHistory(commandNames)
 
 	// Add initial activity (only once during initialization)
-	model.dashboard.AddRecentActivity("info", "MCF TUI started", fmt.Sprintf("Loaded %d agents, %d commands", len(agents), len(commands)))
+	model.dashboard.AddRecentActivity("info", "MCF TUI started", fmt.Sprintf("Loaded %d agents, %d commands", len(model.mcfAdapter.GetAgents()), len(commands)))
+}
+
+// refreshSystemHealth reloads version, integration and agent status onto the dashboard
+func refreshSystemHealth(model *MCFModel) {
+	// Get real system info
+	version := model.mcfAdapter.GetVersion()
+	if version == "" || version == "unknown" {
+		version = "MCF Development"
+	}
+
+	serenaStatus := model.mcfAdapter.GetSerenaStatus()
+
+	// Get real agent data
+	agents := model.mcfAdapter.GetAgents()
+	activeCount := 0
+	agentStatuses := []ui.AgentStatus{}
+
+	for _, agent := range agents {
+		if agent.Status == "active" {
+			activeCount++
+		}
+
+		// Convert MCF agent to dashboard agent status
+		agentStatus := ui.AgentStatus{
+			Name:        agent.Name,
+			Status:      agent.Status,
+			LastSeen:    agent.LastActive,
+			TasksActive: 0, // TODO: Get real task data
+			TasksTotal:  0,
+		}
+		agentStatuses = append(agentStatuses, agentStatus)
+	}
+
+	// Update dashboard with real data
+	model.dashboard.SetSystemHealth(version, serenaStatus, activeCount, len(agents))
+	model.dashboard.SetAgentStatuses(agentStatuses)
 }
 
 func (m MCFModel) Init() tea.Cmd {
 	return tea.Batch(
-		tickCmd(),
+		m.tickCmd(),
+		m.health.pollCmd(),
+		watchConfigCmd(m.config),
 	)
 }
 
-// Periodic update command
-func tickCmd() tea.Cmd {
-	return tea.Tick(time.Second*5, func(t time.Time) tea.Msg {
+// Periodic update command, paced by tui.refresh_rate
+func (m MCFModel) tickCmd() tea.Cmd {
+	interval := m.tickInterval
+	if interval <= 0 {
+		interval = defaultTickInterval
+	}
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
 type tickMsg time.Time
 
+// claudeConnectionCmd runs the Claude connection check off the UI goroutine.
+// force skips the cached result, for checks the user asked for explicitly.
+func claudeConnectionCmd(adapter *mcf.MCFAdapter, force bool) tea.Cmd {
+	return func() tea.Msg {
+		return claudeConnectionMsg(adapter.TestClaudeConnection(mcf.ClaudeConnectionTimeout, force))
+	}
+}
+
+type claudeConnectionMsg mcf.ClaudeConnection
+
 func (m *MCFModel) SetView(view ui.View) {
 	m.navigation.SetView(view)
 
@@ -397,7 +529,23 @@ func (m MCFModel) renderContent() string {
 	}
 }
 
+// renderInstallNotice explains why an operation directory can't be listed
+func (m MCFModel) renderInstallNotice(dir, title string, width, height int) string {
+	guidance := m.installStatus.Guidance(dir)
+	if guidance == "" {
+		return ""
+	}
+
+	content := m.theme.Warning.Render(ui.Icon("warning")+" "+title+" unavailable") + "\n\n"
+	content += m.theme.Body.Render(guidance)
+	return ui.RenderBox(content, title, width, height, m.theme)
+}
+
 func (m MCFModel) renderAgentsView(width, height int) string {
+	if notice := m.renderInstallNotice(filepath.Join(".claude", "agents"), "Agents", width, height); notice != "" {
+		return notice
+	}
+
 	// Main agents list
 	agentsList := m.agentsList.Render(width * 2 / 3)
 
@@ -425,8 +573,15 @@ func (m MCFModel) renderAgentsView(width, height int) string {
 }
 
 func (m MCFModel) renderCommandsView(width, height int) string {
-	// Commands history list
+	if notice := m.renderInstallNotice(filepath.Join(".claude", "commands"), "Commands", width, height); notice != "" {
+		return notice
+	}
+
+	// Commands history list, or past runs with their outcome
 	commandsList := m.commandsList.Render(width * 2 / 3)
+	if m.showHistory {
+		commandsList = m.historyList.Render(width * 2 / 3)
+	}
 
 	// Command details and actions
 	selectedCommand := m.commandsList.GetSelectedItem()
@@ -436,6 +591,7 @@ func (m MCFModel) renderCommandsView(width, height int) string {
 		detailsContent += m.theme.Subtitle.Render("Command Details") + "\n\n"
 		detailsContent += m.theme.Info.Render(selectedCommand.Title) + "\n\n"
 		detailsContent += m.theme.Muted.Render(selectedCommand.Description) + "\n\n"
+		detailsContent += m.renderCommandParameters(selectedCommand.Title)
 
 		// Command actions
 		detailsContent += m.theme.Subtitle.Render("Actions") + "\n"
@@ -443,14 +599,146 @@ func (m MCFModel) renderCommandsView(width, height int) string {
 		detailsContent += m.theme.ListItem.Render("e - Edit Command") + "\n"
 		detailsContent += m.theme.ListItem.Render("d - Delete from History") + "\n"
 		detailsContent += m.theme.ListItem.Render("c - Copy to Clipboard") + "\n"
+		detailsContent += m.theme.ListItem.Render("v - Preview what will run") + "\n"
+		detailsContent += m.theme.ListItem.Render("h - Toggle run history") + "\n"
+
+		if m.preview != nil && m.previewName == selectedCommand.Title {
+			detailsContent += "\n" + m.theme.Subtitle.Render("Will Run") + "\n"
+			detailsContent += m.theme.Muted.Render(m.preview.String()) + "\n"
+		}
 	}
 
+	detailsContent += m.renderPlaybookPanel()
+	detailsContent += m.renderLiveOutput()
+	detailsContent += m.renderLastOutput()
+
 	detailsPanel := ui.RenderBox(detailsContent, "Command Actions", width/3, height-2, m.theme)
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, commandsList, detailsPanel)
 }
 
+// renderCommandParameters lists the inputs a command declares in its frontmatter
+func (m MCFModel) renderCommandParameters(name string) string {
+	if m.mcfAdapter == nil {
+		return ""
+	}
+	command, ok := m.mcfAdapter.GetCommands()[name]
+	if !ok || len(command.Parameters) == 0 {
+		return ""
+	}
+
+	content := m.theme.Subtitle.Render("Parameters") + "\n"
+	for _, p := range command.Parameters {
+		line := p.Name
+		if p.Required {
+			line += " (required)"
+		} else if p.Default != "" {
+			line += fmt.Sprintf(" (default %q)", p.Default)
+		}
+		if p.Description != "" {
+			line += " - " + p.Description
+		}
+		content += m.theme.ListItem.Render(line) + "\n"
+	}
+	return content + "\n"
+}
+
+// renderLiveOutput shows the running command with its latest output lines
+func (m MCFModel) renderLiveOutput() string {
+	if m.running == nil {
+		return ""
+	}
+
+	state, hint := "Running", "esc/ctrl+c cancel"
+	if m.running.cancelled {
+		state, hint = "Cancelling", "waiting for the process to exit"
+	}
+	elapsed := time.Since(m.running.started).Truncate(time.Second)
+	content := "\n" + m.theme.Subtitle.Render(fmt.Sprintf("%s %s: %s (%s)", m.spinner.View(), state, m.running.name, elapsed)) + "\n"
+	content += m.theme.Muted.Render(hint) + "\n"
+	lines := m.liveOutput
+	if len(lines) > liveOutputLines {
+		lines = lines[len(lines)-liveOutputLines:]
+	}
+	for _, line := range lines {
+		content += line + "\n"
+	}
+	return content
+}
+
+// renderLastOutput shows the last command's output, pretty-printing JSON when selected
+func (m MCFModel) renderLastOutput() string {
+	if m.lastResult == nil {
+		return ""
+	}
+
+	output := m.lastResult.Output
+	mode := outputRaw
+	if m.outputMode != outputRaw {
+		maxDepth := 0
+		if m.outputMode == outputCollapsed {
+			maxDepth = 1
+		}
+		if formatted, ok := ui.FormatJSON(output, maxDepth, m.theme); ok {
+			output = formatted
+			mode = m.outputMode
+		}
+	}
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) > lastOutputLines {
+		lines = append(lines[:lastOutputLines], m.theme.Muted.Render(fmt.Sprintf("… %d more lines", len(lines)-lastOutputLines)))
+	}
+
+	content := "\n" + m.theme.Subtitle.Render(fmt.Sprintf("Last Output: %s (%s)", m.lastResultName, mode)) + "\n"
+	content += strings.Join(lines, "\n") + "\n"
+	content += m.theme.Muted.Render("p cycle raw/pretty/collapsed") + "\n"
+	return content
+}
+
+// renderPlaybookPanel shows the queued operations and the outcome of the last bulk run
+func (m MCFModel) renderPlaybookPanel() string {
+	content := ""
+
+	if queued := m.commandsList.MarkedTitles(); len(queued) > 0 {
+		policy := "stop on failure"
+		if m.continueOnError {
+			policy = "continue on failure"
+		}
+		content += "\n" + m.theme.Subtitle.Render(fmt.Sprintf("Queue (%d, %s)", len(queued), policy)) + "\n"
+		for i, title := range queued {
+			content += m.theme.ListItem.Render(fmt.Sprintf("%d. %s", i+1, title)) + "\n"
+		}
+		content += m.theme.Muted.Render("x run • o policy • w save playbook") + "\n"
+	}
+
+	if m.playbook != nil {
+		content += "\n" + m.theme.Subtitle.Render(fmt.Sprintf("%s Running %s (%d/%d)",
+			m.spinner.View(), m.playbook.name, len(m.playbookResults), m.playbook.total)) + "\n"
+	} else if len(m.playbookResults) > 0 {
+		content += "\n" + m.theme.Subtitle.Render("Last Run") + "\n"
+	}
+	if len(m.playbookResults) > 0 {
+		for _, r := range m.playbookResults {
+			switch {
+			case r.Skipped:
+				content += m.theme.Muted.Render("– "+r.Step.Command+" (skipped)") + "\n"
+			case r.Succeeded():
+				content += m.theme.Success.Render(ui.Icon("check")+" "+r.Step.Command) + "\n"
+			default:
+				content += m.theme.Error.Render(ui.Icon("cross")+" "+r.Step.Command) + "\n"
+			}
+		}
+	}
+
+	return content
+}
+
 func (m MCFModel) renderConfigView(width, height int) string {
+	if m.showSettingsFile {
+		return m.renderSettingsFile(width, height)
+	}
+
 	content := m.theme.Title.Render("MCF Configuration") + "\n\n"
 
 	if m.mcfAdapter != nil {
@@ -530,6 +818,31 @@ func (m MCFModel) renderConfigView(width, height int) string {
 	return ui.RenderBox(content, "", width, height, m.theme)
 }
 
+// renderSettingsFile shows settings.json read-only; no key in this mode mutates config
+func (m MCFModel) renderSettingsFile(width, height int) string {
+	path := mcf.SettingsPath(findMCFRoot())
+	content := m.theme.Muted.Render(path+" (read-only)") + "\n\n"
+
+	m.settingsViewer.SetSize(width-6, height-8)
+	content += m.settingsViewer.View() + "\n\n"
+	content += m.theme.Muted.Render(m.settingsViewer.StatusLine() + " │ j/k scroll │ / search │ s close")
+
+	return ui.RenderBox(content, "Settings File", width, height, m.theme)
+}
+
+// settingsFileText loads settings.json as highlighted YAML for the settings viewer
+func (m MCFModel) settingsFileText() string {
+	settings, err := mcf.LoadRawSettings(findMCFRoot())
+	if err != nil {
+		return m.theme.Error.Render("Unable to read settings: " + err.Error())
+	}
+	text, err := mcf.FormatSettingsYAML(settings)
+	if err != nil {
+		return m.theme.Error.Render("Unable to read settings: " + err.Error())
+	}
+	return ui.HighlightYAML(text, m.theme)
+}
+
 func (m MCFModel) renderCommandBar(width, height int) string {
 	// Command input
 	commandInput := m.commandInput.Render(width)
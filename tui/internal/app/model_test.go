@@ -1,12 +1,14 @@
 package app
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 
+	"mcf-dev/tui/internal/mcf"
 	testutils "mcf-dev/tui/internal/testing"
 	"mcf-dev/tui/internal/ui"
 )
@@ -262,6 +264,38 @@ func TestMCFModel_View(t *testing.T) {
 	})
 }
 
+func TestMCFModel_InstallNotice(t *testing.T) {
+	t.Run("should show install guidance instead of lists when directories are missing", func(t *testing.T) {
+		model := InitialModel()
+		model.ready = true
+		model.width = 100
+		model.height = 30
+		model.installStatus = mcf.InstallationStatus{
+			MCFRoot:     "/tmp/project",
+			MissingDirs: []string{".claude/commands"},
+		}
+
+		model.SetView(ui.CommandsView)
+		view := model.View()
+		assert.Contains(t, view, "not installed", "Should explain the missing install")
+		assert.NotContains(t, view, "Loading", "Should not show a loading state")
+	})
+
+	t.Run("should report no operations for an empty directory", func(t *testing.T) {
+		model := InitialModel()
+		model.ready = true
+		model.width = 100
+		model.height = 30
+		model.installStatus = mcf.InstallationStatus{
+			MCFRoot:   "/tmp/project",
+			EmptyDirs: []string{filepath.Join(".claude", "agents")},
+		}
+
+		model.SetView(ui.AgentsView)
+		assert.Contains(t, model.View(), "No operations found")
+	})
+}
+
 func TestMCFModel_InteractionTime(t *testing.T) {
 	t.Run("should track last interaction time", func(t *testing.T) {
 		model := InitialModel()
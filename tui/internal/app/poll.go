@@ -0,0 +1,114 @@
+package app
+
+import (
+	"time"
+
+	"mcf-dev/tui/internal/mcf"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	defaultHealthInterval = time.Second // matches the tui.refresh_rate default
+	maxHealthBackoff      = time.Minute
+	disconnectedAfter     = 3 // consecutive failures before the dashboard shows disconnected
+)
+
+// Connection states shown on the dashboard
+const (
+	healthConnected    = "connected"
+	healthDegraded     = "degraded"
+	healthDisconnected = "disconnected"
+)
+
+// healthPoller schedules dashboard health checks, backing off while they fail.
+// Polling pauses when the dashboard is hidden and resumes when it is shown again.
+type healthPoller struct {
+	interval time.Duration
+	check    func() error
+	failures int
+	active   bool // a poll is in flight
+	seq      int  // identifies the current poll chain so stale results are dropped
+}
+
+type healthPollMsg struct {
+	seq int
+	err error
+}
+
+func newHealthPoller(interval time.Duration, check func() error) healthPoller {
+	if interval <= 0 {
+		interval = defaultHealthInterval
+	}
+	// The first poll is issued by Init, so a poller with a check starts active
+	return healthPoller{interval: interval, check: check, active: check != nil}
+}
+
+// delay is the wait before the next poll: the refresh interval doubled per failure, capped
+func (p *healthPoller) delay() time.Duration {
+	d := p.interval
+	for i := 0; i < p.failures && d < maxHealthBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxHealthBackoff)
+}
+
+// state summarizes the recent poll results
+func (p *healthPoller) state() string {
+	switch {
+	case p.failures == 0:
+		return healthConnected
+	case p.failures < disconnectedAfter:
+		return healthDegraded
+	default:
+		return healthDisconnected
+	}
+}
+
+// schedule starts a new poll after the current delay; nil when there is nothing to poll
+func (p *healthPoller) schedule() tea.Cmd {
+	if p.check == nil {
+		return nil
+	}
+	p.seq++
+	p.active = true
+	return p.pollCmd()
+}
+
+// pollCmd runs one check for the current chain after the current delay
+func (p *healthPoller) pollCmd() tea.Cmd {
+	if p.check == nil {
+		return nil
+	}
+	seq, check := p.seq, p.check
+	return tea.Tick(p.delay(), func(time.Time) tea.Msg {
+		return healthPollMsg{seq: seq, err: check()}
+	})
+}
+
+// record applies a poll result, returning false for results from a superseded chain
+func (p *healthPoller) record(msg healthPollMsg) bool {
+	if msg.seq != p.seq {
+		return false
+	}
+	p.active = false
+	if msg.err != nil {
+		p.failures++
+	} else {
+		p.failures = 0
+	}
+	return true
+}
+
+// loadHealthInterval reads tui.refresh_rate (milliseconds) from settings.json
+func loadHealthInterval(mcfRoot string) time.Duration {
+	settings, err := mcf.LoadRawSettings(mcfRoot)
+	if err != nil {
+		return defaultHealthInterval
+	}
+	tuiSettings, _ := settings["tui"].(map[string]interface{})
+	if ms, ok := tuiSettings["refresh_rate"].(float64); ok && ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return defaultHealthInterval
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mcf-dev/tui/internal/ui"
+)
+
+func TestHealthPoller_Backoff(t *testing.T) {
+	t.Run("should double the delay per failure up to the cap", func(t *testing.T) {
+		p := newHealthPoller(time.Second, func() error { return nil })
+
+		var delays []time.Duration
+		for i := 0; i < 8; i++ {
+			delays = append(delays, p.delay())
+			p.failures++
+		}
+
+		assert.Equal(t, []time.Duration{
+			time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second,
+			16 * time.Second, 32 * time.Second, time.Minute, time.Minute,
+		}, delays)
+	})
+
+	t.Run("should move from degraded to disconnected and recover", func(t *testing.T) {
+		p := newHealthPoller(time.Second, func() error { return nil })
+		fail := errors.New("unreachable")
+
+		assert.Equal(t, healthConnected, p.state())
+		for _, want := range []string{healthDegraded, healthDegraded, healthDisconnected} {
+			p.schedule()
+			require.True(t, p.record(healthPollMsg{seq: p.seq, err: fail}))
+			assert.Equal(t, want, p.state())
+		}
+
+		p.schedule()
+		require.True(t, p.record(healthPollMsg{seq: p.seq}))
+		assert.Equal(t, healthConnected, p.state())
+		assert.Equal(t, time.Second, p.delay(), "Delay should reset after recovery")
+	})
+
+	t.Run("should ignore results from a superseded chain", func(t *testing.T) {
+		p := newHealthPoller(time.Second, func() error { return nil })
+		p.schedule()
+		stale := p.seq
+		p.schedule()
+
+		assert.False(t, p.record(healthPollMsg{seq: stale, err: errors.New("late")}))
+		assert.Equal(t, 0, p.failures)
+	})
+
+	t.Run("should fall back to the default interval", func(t *testing.T) {
+		p := newHealthPoller(0, nil)
+
+		assert.Equal(t, defaultHealthInterval, p.delay())
+		assert.Nil(t, p.schedule(), "Nothing to poll without a check")
+		assert.False(t, p.active)
+	})
+}
+
+func TestMCFModelUpdate_HealthPolling(t *testing.T) {
+	newPollingModel := func(check func() error) MCFModel {
+		model := InitialModel()
+		model.mcfAdapter = nil
+		model.health = newHealthPoller(time.Millisecond, check)
+		return model
+	}
+
+	t.Run("should show degraded state and keep polling on the dashboard", func(t *testing.T) {
+		model := newPollingModel(func() error { return errors.New("settings.json unreadable") })
+
+		newModel, cmd := model.Update(healthPollMsg{seq: model.health.seq, err: errors.New("settings.json unreadable")})
+		updated := newModel.(MCFModel)
+
+		assert.Equal(t, healthDegraded, updated.dashboard.ConnectionState())
+		assert.True(t, updated.health.active)
+		require.NotNil(t, cmd, "Next poll should be scheduled")
+		assert.IsType(t, healthPollMsg{}, cmd())
+	})
+
+	t.Run("should pause when the dashboard is hidden and resume when shown", func(t *testing.T) {
+		model := newPollingModel(func() error { return nil })
+		model.SetView(ui.AgentsView)
+
+		newModel, cmd := model.Update(healthPollMsg{seq: model.health.seq})
+		updated := newModel.(MCFModel)
+
+		assert.Nil(t, cmd, "Polling should pause off the dashboard")
+		assert.False(t, updated.health.active)
+
+		newModel, cmd = updated.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		updated = newModel.(MCFModel)
+
+		assert.Equal(t, ui.DashboardView, updated.navigation.GetCurrentView())
+		assert.True(t, updated.health.active)
+		require.NotNil(t, cmd, "Returning to the dashboard should resume polling")
+		msg, ok := cmd().(healthPollMsg)
+		require.True(t, ok)
+		assert.Equal(t, updated.health.seq, msg.seq)
+	})
+}
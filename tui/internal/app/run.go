@@ -0,0 +1,172 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"mcf-dev/tui/internal/mcf"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxLiveOutputLines bounds the live output kept for the running command
+const maxLiveOutputLines = 200
+
+// liveOutputLines is how many of the most recent live lines the commands view shows
+const liveOutputLines = 10
+
+// stopWait bounds how long quitting waits for a cancelled command to exit
+const stopWait = 3 * time.Second
+
+// commandRun is a command executing in the background from the commands view.
+// Output lines arrive on lines; the final result arrives on done after lines is closed.
+type commandRun struct {
+	name    string
+	started time.Time
+	lines   chan mcf.OutputLine
+	done    chan commandDoneMsg
+	exited  chan struct{} // closed once the command has returned
+
+	cancel    context.CancelFunc
+	cancelled bool
+}
+
+// commandOutputMsg carries one live output line from a running command
+type commandOutputMsg struct {
+	run  *commandRun
+	line mcf.OutputLine
+}
+
+// commandDoneMsg reports that a background command finished
+type commandDoneMsg struct {
+	run      *commandRun
+	result   *mcf.CommandResult
+	err      error
+	duration time.Duration
+}
+
+// startCommandRun executes name in a goroutine, streaming its output
+func startCommandRun(adapter *mcf.MCFAdapter, name string, args []string) *commandRun {
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &commandRun{
+		name:    name,
+		started: time.Now(),
+		lines:   make(chan mcf.OutputLine, 256),
+		done:    make(chan commandDoneMsg, 1),
+		exited:  make(chan struct{}),
+		cancel:  cancel,
+	}
+
+	go func() {
+		defer cancel()
+		result, err := adapter.ExecuteCommandStreaming(ctx, name, args, func(line mcf.OutputLine) {
+			// Drop output nobody will read once the run is cancelled
+			select {
+			case run.lines <- line:
+			case <-ctx.Done():
+			}
+		})
+		close(run.exited)
+		close(run.lines)
+		run.done <- commandDoneMsg{run: run, result: result, err: err, duration: time.Since(run.started)}
+	}()
+
+	return run
+}
+
+// Cancel kills the running command; its result still arrives as a commandDoneMsg
+func (r *commandRun) Cancel() {
+	r.cancelled = true
+	r.cancel()
+}
+
+// Stop cancels the command and waits briefly for it to exit, for use before quitting
+func (r *commandRun) Stop() {
+	r.Cancel()
+	select {
+	case <-r.exited:
+	case <-time.After(stopWait):
+	}
+}
+
+// next waits for the run's next output line, or for its result once output ends
+func (r *commandRun) next() tea.Cmd {
+	return func() tea.Msg {
+		if line, ok := <-r.lines; ok {
+			return commandOutputMsg{run: r, line: line}
+		}
+		return <-r.done
+	}
+}
+
+// playbookRun is a playbook executing in the background from the commands view.
+// Step results arrive on steps as each finishes; steps is closed after the last.
+type playbookRun struct {
+	name   string
+	total  int
+	steps  chan mcf.StepResult
+	exited chan struct{} // closed once the playbook has returned
+
+	cancel    context.CancelFunc
+	cancelled bool
+}
+
+// playbookStepMsg carries the result of one finished playbook step
+type playbookStepMsg struct {
+	run    *playbookRun
+	result mcf.StepResult
+}
+
+// playbookDoneMsg reports that a background playbook finished
+type playbookDoneMsg struct {
+	run *playbookRun
+}
+
+// startPlaybookRun executes pb in a goroutine, reporting each step as it finishes
+func startPlaybookRun(adapter *mcf.MCFAdapter, pb mcf.Playbook) *playbookRun {
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &playbookRun{
+		name:   pb.Name,
+		total:  len(pb.Steps),
+		steps:  make(chan mcf.StepResult, len(pb.Steps)),
+		exited: make(chan struct{}),
+		cancel: cancel,
+	}
+
+	go func() {
+		defer cancel()
+		// steps holds every result, so sending never blocks
+		adapter.RunPlaybookContext(ctx, pb, func(result mcf.StepResult) {
+			run.steps <- result
+		})
+		close(run.exited)
+		close(run.steps)
+	}()
+
+	return run
+}
+
+// Cancel kills the running step and skips the rest; their results still arrive
+func (r *playbookRun) Cancel() {
+	r.cancelled = true
+	r.cancel()
+}
+
+// Stop cancels the playbook and waits briefly for it to exit, for use before quitting
+func (r *playbookRun) Stop() {
+	r.Cancel()
+	select {
+	case <-r.exited:
+	case <-time.After(stopWait):
+	}
+}
+
+// next waits for the playbook's next step result, or reports it done once results end
+func (r *playbookRun) next() tea.Cmd {
+	return func() tea.Msg {
+		if result, ok := <-r.steps; ok {
+			return playbookStepMsg{run: r, result: result}
+		}
+		return playbookDoneMsg{run: r}
+	}
+}
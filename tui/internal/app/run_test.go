@@ -0,0 +1,221 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mcf-dev/tui/internal/mcf"
+	"mcf-dev/tui/internal/ui"
+)
+
+// newRunModel returns a commands-view model whose adapter has one shell command, "ci:count"
+func newRunModel(t *testing.T, script string) MCFModel {
+	t.Helper()
+	t.Setenv("MCF_TUI_LOG_DIR", t.TempDir())
+
+	root := t.TempDir()
+	files := map[string]string{
+		".claude/settings.json":          `{"version": "1.0.0"}`,
+		".claude/agents/orchestrator.md": "# Orchestrator",
+		".claude/commands/ci/count.md":   "command: " + script + "\n# Runs in bash",
+	}
+	for rel, content := range files {
+		path := filepath.Join(root, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	adapter, err := mcf.NewMCFAdapter(root)
+	require.NoError(t, err)
+
+	model := InitialModel()
+	model.ready = true
+	model.width = 200
+	model.height = 60
+	model.mcfAdapter = adapter
+	model.SetView(ui.CommandsView)
+	return model
+}
+
+func TestCommandRun_Streaming(t *testing.T) {
+	t.Run("should show output lines before the result arrives", func(t *testing.T) {
+		model := newRunModel(t, "echo one; echo two")
+
+		next := model.startCommand("ci:count", nil)
+		assert.Contains(t, model.View(), "Running: ci:count")
+
+		var live [][]string
+		for model.running != nil {
+			msg := awaitRunMsg(t, next)
+			newModel, cmd := model.Update(msg)
+			model = newModel.(MCFModel)
+			next = cmd
+			if _, ok := msg.(commandOutputMsg); ok {
+				live = append(live, append([]string(nil), model.liveOutput...))
+				assert.Nil(t, model.lastResult, "Result should not be set while output streams")
+			}
+		}
+
+		assert.Equal(t, [][]string{{"one"}, {"one", "two"}}, live)
+		require.NotNil(t, model.lastResult)
+		assert.Equal(t, "one\ntwo\n", model.lastResult.Output, "Final result keeps the full output")
+		assert.Equal(t, "ci:count", model.lastResultName)
+		assert.NotContains(t, model.View(), "Running:")
+	})
+
+	t.Run("should keep the spinner ticking only while running", func(t *testing.T) {
+		model := newRunModel(t, "echo done")
+
+		model.startCommand("ci:count", nil)
+		_, cmd := model.Update(model.spinner.Tick())
+		assert.NotNil(t, cmd, "Spinner should schedule its next frame while running")
+
+		model.running = nil
+		_, cmd = model.Update(model.spinner.Tick())
+		assert.Nil(t, cmd, "Spinner should stop once the command is done")
+	})
+}
+
+func TestCommandRun_Cancel(t *testing.T) {
+	model := newRunModel(t, "echo started; sleep 30")
+
+	next := model.startCommand("ci:count", nil)
+	msg := awaitRunMsg(t, next)
+	require.IsType(t, commandOutputMsg{}, msg)
+	newModel, next := model.Update(msg)
+	model = newModel.(MCFModel)
+
+	newModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	model = newModel.(MCFModel)
+	require.NotNil(t, model.running, "Ctrl+C should cancel the command, not quit")
+	assert.True(t, model.running.cancelled)
+	assert.Contains(t, model.View(), "Cancelling: ci:count")
+	assert.Equal(t, ui.CommandsView, model.navigation.GetCurrentView())
+
+	start := time.Now()
+	for model.running != nil {
+		newModel, next = model.Update(awaitRunMsg(t, next))
+		model = newModel.(MCFModel)
+	}
+
+	assert.Less(t, time.Since(start), 5*time.Second, "Cancelled command should stop promptly")
+	require.NotNil(t, model.lastResult)
+	assert.True(t, model.lastResult.Cancelled)
+	assert.Contains(t, model.lastResult.Error, "cancelled")
+}
+
+func TestCommandRun_History(t *testing.T) {
+	model := newRunModel(t, "echo ok")
+	runToCompletion := func(next tea.Cmd) {
+		for model.running != nil {
+			newModel, cmd := model.Update(awaitRunMsg(t, next))
+			model = newModel.(MCFModel)
+			next = cmd
+		}
+	}
+
+	runToCompletion(model.startCommand("ci:count", []string{"--fast"}))
+
+	newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	model = newModel.(MCFModel)
+	require.True(t, model.showHistory)
+	view := model.View()
+	assert.Contains(t, view, "Run History")
+	assert.Contains(t, view, "ci:count --fast")
+	assert.Contains(t, view, "passed")
+
+	newModel, next := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = newModel.(MCFModel)
+	require.NotNil(t, model.running, "Enter should re-run the selected entry")
+	runToCompletion(next)
+
+	entries := model.mcfAdapter.History().Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, []string{"--fast"}, entries[0].Args, "Re-run should reuse the recorded arguments")
+
+	newModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	model = newModel.(MCFModel)
+	assert.False(t, model.showHistory)
+}
+
+func TestPlaybookRun(t *testing.T) {
+	steps := []mcf.PlaybookStep{{Command: "ci:count"}, {Command: "ci:count", Args: []string{"again"}}}
+
+	t.Run("should return before the steps run and report each as it finishes", func(t *testing.T) {
+		model := newRunModel(t, "echo ok")
+
+		next := model.runPlaybook(mcf.Playbook{Name: "ci", Steps: steps})
+		require.NotNil(t, model.playbook, "Playbook should run in the background")
+		assert.Empty(t, model.playbookResults)
+		assert.Contains(t, model.View(), "Running ci (0/2)")
+
+		var progress []int
+		for model.playbook != nil {
+			msg := awaitRunMsg(t, next)
+			newModel, cmd := model.Update(msg)
+			model = newModel.(MCFModel)
+			next = cmd
+			if _, ok := msg.(playbookStepMsg); ok {
+				progress = append(progress, len(model.playbookResults))
+			}
+		}
+
+		assert.Equal(t, []int{1, 2}, progress)
+		for _, r := range model.playbookResults {
+			assert.True(t, r.Succeeded())
+		}
+		view := model.View()
+		assert.Contains(t, view, "Last Run")
+		assert.NotContains(t, view, "Running ci")
+		assert.Contains(t, model.logViewer.Render(300), "[2/2] ci:count: ok")
+	})
+
+	t.Run("should skip the remaining steps when cancelled", func(t *testing.T) {
+		model := newRunModel(t, "sleep 30")
+
+		next := model.runPlaybook(mcf.Playbook{Name: "ci", Steps: steps})
+		assert.Nil(t, model.runPlaybook(mcf.Playbook{Name: "other", Steps: steps}), "Only one playbook runs at a time")
+
+		newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		model = newModel.(MCFModel)
+		require.NotNil(t, model.playbook, "Esc should cancel the playbook, not leave the view")
+		assert.True(t, model.playbook.cancelled)
+		assert.Equal(t, ui.CommandsView, model.navigation.GetCurrentView())
+
+		start := time.Now()
+		for model.playbook != nil {
+			newModel, next = model.Update(awaitRunMsg(t, next))
+			model = newModel.(MCFModel)
+		}
+
+		assert.Less(t, time.Since(start), 5*time.Second, "Cancelled playbook should stop promptly")
+		require.Len(t, model.playbookResults, 2)
+		assert.False(t, model.playbookResults[0].Succeeded())
+		assert.True(t, model.playbookResults[1].Skipped)
+	})
+}
+
+// awaitRunMsg runs cmd, which may be a batch, until it yields a command or playbook run message
+func awaitRunMsg(t *testing.T, cmd tea.Cmd) tea.Msg {
+	t.Helper()
+	require.NotNil(t, cmd)
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, c := range batch {
+			if c == nil {
+				continue
+			}
+			switch m := c().(type) {
+			case commandOutputMsg, commandDoneMsg, playbookStepMsg, playbookDoneMsg:
+				return m
+			}
+		}
+		t.Fatal("Batch should include the run's next message")
+	}
+	return msg
+}
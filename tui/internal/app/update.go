@@ -2,10 +2,14 @@ package app
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"mcf-dev/tui/internal/mcf"
 	"mcf-dev/tui/internal/ui"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -23,28 +27,52 @@ func (m MCFModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		// An active settings search takes all keys, including the global ones
+		if m.showSettingsFile && m.settingsViewer.Searching() && m.navigation.GetCurrentView() == ui.ConfigView {
+			return m.updateConfig(msg)
+		}
+
+		// While a command runs, ctrl+c and esc cancel it instead of quitting or going back
+		if m.running != nil && (msg.Type == tea.KeyCtrlC || key.Matches(msg, m.keys.Back)) {
+			m.cancelRunning()
+			return m, nil
+		}
+		if m.playbook != nil && (msg.Type == tea.KeyCtrlC || key.Matches(msg, m.keys.Back)) {
+			m.cancelPlaybook()
+			return m, nil
+		}
+
 		// Global key handlers
-		switch msg.String() {
-		case "ctrl+c", "q":
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			if m.running != nil {
+				// Don't leave the command running detached after the TUI exits
+				m.running.Stop()
+			}
+			if m.playbook != nil {
+				m.playbook.Stop()
+			}
 			return m, tea.Quit
 
-		case "?":
+		case key.Matches(msg, m.keys.Help):
 			m.ToggleHelp()
 			return m, nil
 
-		case "tab":
+		case key.Matches(msg, m.keys.NextView):
 			m.nextView()
-			return m, nil
+			cmd := m.resumeHealthPoll()
+			return m, cmd
 
-		case "shift+tab":
+		case key.Matches(msg, m.keys.PrevView):
 			m.prevView()
-			return m, nil
+			cmd := m.resumeHealthPoll()
+			return m, cmd
 
-		case ":":
+		case key.Matches(msg, m.keys.CommandBar):
 			m.SetView(ui.CommandBarView)
 			return m, nil
 
-		case "esc":
+		case key.Matches(msg, m.keys.Back):
 			currentView := m.navigation.GetCurrentView()
 			if currentView == ui.CommandBarView {
 				// Return to previous view
@@ -57,9 +85,10 @@ func (m MCFModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Close help
 				m.ToggleHelp()
 			}
-			return m, nil
+			cmd := m.resumeHealthPoll()
+			return m, cmd
 
-		case "r":
+		case key.Matches(msg, m.keys.Refresh):
 			// Refresh current view
 			if m.navigation.GetCurrentView() == ui.DashboardView {
 				m.dashboard.Update()
@@ -89,6 +118,62 @@ func (m MCFModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateCommandBar(msg)
 		}
 
+	case claudeConnectionMsg:
+		m.applyClaudeConnection(mcf.ClaudeConnection(msg))
+		return m, nil
+
+	case healthPollMsg:
+		cmd := m.applyHealthPoll(msg)
+		return m, cmd
+
+	case commandOutputMsg:
+		if msg.run == m.running {
+			m.liveOutput = append(m.liveOutput, msg.line.Text)
+			if len(m.liveOutput) > maxLiveOutputLines {
+				m.liveOutput = m.liveOutput[len(m.liveOutput)-maxLiveOutputLines:]
+			}
+		}
+		return m, msg.run.next()
+
+	case commandDoneMsg:
+		if msg.run == m.running {
+			m.running = nil
+			m.recordCommandResult(msg.run.name, msg.result, msg.err)
+			if m.showHistory {
+				m.refreshRunHistory()
+			}
+		}
+		return m, nil
+
+	case configReloadedMsg:
+		m.reloadConfig()
+		return m, awaitConfigChange(msg.changes)
+
+	case configWatchFailedMsg:
+		logConfigProblem(m.logViewer, msg.err)
+		return m, nil
+
+	case playbookStepMsg:
+		if msg.run == m.playbook {
+			m.recordPlaybookStep(msg.result)
+		}
+		return m, msg.run.next()
+
+	case playbookDoneMsg:
+		if msg.run == m.playbook {
+			m.playbook = nil
+		}
+		return m, nil
+
+	case spinner.TickMsg:
+		// Keep the spinner moving only while a command or playbook runs
+		if m.running == nil && m.playbook == nil {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
 	case tickMsg:
 		// Periodic background updates
 		m.dashboard.Update()
@@ -120,16 +205,84 @@ func (m MCFModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		cmds = append(cmds, tickCmd())
+		cmds = append(cmds, m.tickCmd())
 
 	default:
 		// Handle unknown message types gracefully by returning tick command
-		cmds = append(cmds, tickCmd())
+		cmds = append(cmds, m.tickCmd())
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
+// applyHealthPoll updates the dashboard connection state and schedules the next poll.
+// Polling stops while the dashboard is hidden; resumeHealthPoll restarts it.
+func (m *MCFModel) applyHealthPoll(msg healthPollMsg) tea.Cmd {
+	previous := m.health.state()
+	if !m.health.record(msg) {
+		return nil
+	}
+
+	state := m.health.state()
+	m.dashboard.SetConnectionState(state)
+	if state == healthConnected && m.mcfAdapter != nil {
+		refreshSystemHealth(m)
+	}
+
+	if state != previous {
+		level, message := "WARN", fmt.Sprintf("MCF health check %s", state)
+		if msg.err != nil {
+			message += ": " + msg.err.Error()
+		}
+		if state == healthConnected {
+			level, message = "INFO", "MCF health check recovered"
+		}
+		m.logViewer.AddLog(ui.LogEntry{
+			Timestamp: time.Now(),
+			Level:     level,
+			Component: "health",
+			Message:   message,
+		})
+	}
+
+	if m.navigation.GetCurrentView() != ui.DashboardView {
+		return nil
+	}
+	return m.health.schedule()
+}
+
+// resumeHealthPoll restarts paused polling once the dashboard is visible again
+func (m *MCFModel) resumeHealthPoll() tea.Cmd {
+	if m.health.active || m.navigation.GetCurrentView() != ui.DashboardView {
+		return nil
+	}
+	return m.health.schedule()
+}
+
+// applyClaudeConnection surfaces a connection check result on the dashboard and in the logs
+func (m *MCFModel) applyClaudeConnection(conn mcf.ClaudeConnection) {
+	m.dashboard.SetClaudeStatus(conn.Status)
+
+	if conn.OK() {
+		m.dashboard.AddRecentActivity("info", "claude", "Connection OK")
+		m.logViewer.AddLog(ui.LogEntry{
+			Timestamp: conn.CheckedAt,
+			Level:     "INFO",
+			Component: "claude",
+			Message:   "✓ Claude connection: " + conn.Detail,
+		})
+		return
+	}
+
+	m.dashboard.AddRecentActivity("error", "claude", fmt.Sprintf("%s: %s", conn.Status, conn.Remediation))
+	m.logViewer.AddLog(ui.LogEntry{
+		Timestamp: conn.CheckedAt,
+		Level:     "ERROR",
+		Component: "claude",
+		Message:   fmt.Sprintf("✗ Claude connection %s: %s. %s", conn.Status, conn.Detail, conn.Remediation),
+	})
+}
+
 // updateAgentsFromMCF updates agent data from the real MCF system
 func (m *MCFModel) updateAgentsFromMCF() {
 	if m.mcfAdapter == nil {
@@ -225,8 +378,9 @@ func (m MCFModel) updateDashboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 						Timestamp: time.Now(),
 						Level:     "INFO",
 						Component: "dashboard",
-						Message:   fmt.Sprintf("✓ %s: %s", action.Label, result.Output),
+						Message:   fmt.Sprintf("✓ %s: %s", action.Label, m.resultOutput(result)),
 					})
+					m.logStderr("dashboard", action.Command, result)
 
 					// Add to dashboard recent activity
 					m.dashboard.AddRecentActivity("command", action.Command, "Executed successfully")
@@ -260,6 +414,20 @@ func (m MCFModel) updateDashboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case "t":
+		// Test Claude connection in the background, bypassing the cache so a fresh `claude /login` shows up
+		if m.mcfAdapter == nil {
+			m.logViewer.AddLog(ui.LogEntry{
+				Timestamp: time.Now(),
+				Level:     "WARN",
+				Component: "dashboard",
+				Message:   "⚠ Claude connection test: MCF adapter not available",
+			})
+			return m, nil
+		}
+		m.dashboard.AddRecentActivity("info", "claude", "Testing connection...")
+		return m, claudeConnectionCmd(m.mcfAdapter, true)
+
 	case "1", "2", "3", "4", "5", "6":
 		// Quick action shortcuts
 		idx := int(msg.String()[0] - '1')
@@ -341,36 +509,83 @@ func (m MCFModel) updateAgents(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m MCFModel) updateCommands(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	if m.showHistory {
+		return m.updateRunHistory(msg)
+	}
+
 	switch msg.String() {
 	case "enter":
-		// Re-execute command using real MCF integration
+		// Re-execute command using real MCF integration, streaming its output
 		selectedCommand := m.commandsList.GetSelectedItem()
 		if selectedCommand != nil && m.mcfAdapter != nil {
-			m.commandInput.AddToHistory(selectedCommand.Title)
+			cmd = m.runCommand(selectedCommand.Title, nil)
+		}
+
+	case "h":
+		// Show past runs instead of the command list
+		m.showHistory = true
+		m.refreshRunHistory()
+
+	case " ":
+		// Queue the selected command for a bulk run
+		m.commandsList.ToggleMark()
+
+	case "s":
+		// Toggle combined vs separated stdout/stderr in result logs
+		m.separateStreams = !m.separateStreams
+		mode := "combined"
+		if m.separateStreams {
+			mode = "separate stdout/stderr"
+		}
+		m.logViewer.AddLog(ui.LogEntry{
+			Timestamp: time.Now(),
+			Level:     "INFO",
+			Component: "commands",
+			Message:   "Command output shown as " + mode,
+		})
+
+	case "v":
+		// Preview the resolved process for the selected command without running it
+		m.previewSelectedCommand()
+
+	case "p":
+		// Cycle last output display: raw, pretty JSON, collapsed JSON
+		m.outputMode = (m.outputMode + 1) % 3
 
-			// Execute the real MCF command
-			result, err := m.mcfAdapter.ExecuteCommand(selectedCommand.Title, []string{})
+	case "f":
+		// Cycle the category filter: all, then each category in turn
+		m.commandsList.NextCategoryFilter()
 
-			if err == nil && result.Success {
+	case "o":
+		// Toggle stop/continue on failure for bulk runs
+		m.continueOnError = !m.continueOnError
+
+	case "x":
+		// Run queued commands in order
+		queued := m.commandsList.MarkedTitles()
+		if len(queued) > 0 {
+			cmd = m.runPlaybook(m.queuedPlaybook("queue"))
+			m.commandsList.ClearMarks()
+		}
+
+	case "w":
+		// Save the queue as a reusable playbook
+		if len(m.commandsList.MarkedTitles()) > 0 && m.mcfAdapter != nil {
+			name := "playbook-" + time.Now().Format("20060102-150405")
+			path, err := m.mcfAdapter.SavePlaybook(m.queuedPlaybook(name))
+			if err != nil {
 				m.logViewer.AddLog(ui.LogEntry{
 					Timestamp: time.Now(),
-					Level:     "INFO",
+					Level:     "ERROR",
 					Component: "commands",
-					Message:   fmt.Sprintf("Executed: %s - %s", selectedCommand.Title, result.Output),
+					Message:   "Failed to save playbook: " + err.Error(),
 				})
 			} else {
-				errorMsg := "Unknown error"
-				if err != nil {
-					errorMsg = err.Error()
-				} else if !result.Success {
-					errorMsg = result.Error
-				}
-
 				m.logViewer.AddLog(ui.LogEntry{
 					Timestamp: time.Now(),
-					Level:     "ERROR",
+					Level:     "INFO",
 					Component: "commands",
-					Message:   fmt.Sprintf("Failed to execute %s: %s", selectedCommand.Title, errorMsg),
+					Message:   fmt.Sprintf("Saved playbook %s (run with :playbook %s)", path, name),
 				})
 			}
 		}
@@ -405,6 +620,267 @@ func (m MCFModel) updateCommands(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// resultOutput returns the output to log for a result: stdout only when streams are separated
+func (m *MCFModel) resultOutput(result *mcf.CommandResult) string {
+	if m.separateStreams {
+		return result.Stdout
+	}
+	return result.Output
+}
+
+// logStderr logs a successful command's stderr as a warning when streams are separated
+func (m *MCFModel) logStderr(component, name string, result *mcf.CommandResult) {
+	if !m.separateStreams || strings.TrimSpace(result.Stderr) == "" {
+		return
+	}
+	m.logViewer.AddLog(ui.LogEntry{
+		Timestamp: time.Now(),
+		Level:     "WARN",
+		Component: component,
+		Message:   fmt.Sprintf("%s stderr: %s", name, result.Stderr),
+	})
+}
+
+// updateRunHistory handles keys while the run history replaces the command list
+func (m MCFModel) updateRunHistory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "h":
+		m.showHistory = false
+
+	case "enter":
+		// Re-run the selected entry with the same arguments
+		if selected := m.historyList.GetSelectedItem(); selected != nil && m.mcfAdapter != nil {
+			entry := selected.Metadata["entry"].(mcf.HistoryEntry)
+			cmd = m.runCommand(entry.Command, entry.Args)
+		}
+
+	default:
+		m.historyList, cmd = m.historyList.Update(tea.KeyMsg(msg))
+	}
+
+	return m, cmd
+}
+
+// refreshRunHistory loads the adapter's run history into the history list, newest first
+func (m *MCFModel) refreshRunHistory() {
+	if m.mcfAdapter == nil || m.mcfAdapter.History() == nil {
+		m.historyList.SetItems(nil)
+		return
+	}
+
+	entries := m.mcfAdapter.History().Entries()
+	items := make([]ui.ListItem, len(entries))
+	for i, entry := range entries {
+		status := "passed"
+		if entry.Cancelled {
+			status = "stopped"
+		} else if !entry.Success {
+			status = "failed"
+		}
+		items[i] = ui.ListItem{
+			Title:  strings.TrimSpace(entry.Command + " " + strings.Join(entry.Args, " ")),
+			Status: status,
+			Description: fmt.Sprintf("%s · %s · exit %d",
+				entry.Timestamp.Local().Format("2006-01-02 15:04:05"), entry.Duration(), entry.ExitCode),
+			Metadata: map[string]interface{}{"entry": entry},
+		}
+	}
+	m.historyList.SetItems(items)
+}
+
+// runCommand starts name with args unless another command is still running
+func (m *MCFModel) runCommand(name string, args []string) tea.Cmd {
+	if m.running != nil {
+		m.logViewer.AddLog(ui.LogEntry{
+			Timestamp: time.Now(),
+			Level:     "WARN",
+			Component: "commands",
+			Message:   fmt.Sprintf("%s is still running", m.running.name),
+		})
+		return nil
+	}
+
+	m.commandInput.AddToHistory(name)
+	m.preview = nil
+	return m.startCommand(name, args)
+}
+
+// startCommand runs name in the background, returning commands that deliver its
+// output lines and result and keep the spinner ticking
+func (m *MCFModel) startCommand(name string, args []string) tea.Cmd {
+	m.running = startCommandRun(m.mcfAdapter, name, args)
+	m.liveOutput = nil
+	return tea.Batch(m.spinner.Tick, m.running.next())
+}
+
+// cancelRunning kills the running command, logging the request once
+func (m *MCFModel) cancelRunning() {
+	if m.running.cancelled {
+		return
+	}
+	m.running.Cancel()
+	m.logViewer.AddLog(ui.LogEntry{
+		Timestamp: time.Now(),
+		Level:     "WARN",
+		Component: "commands",
+		Message:   "Cancelling " + m.running.name,
+	})
+}
+
+// recordCommandResult keeps a finished command's result for display and logs the outcome
+func (m *MCFModel) recordCommandResult(name string, result *mcf.CommandResult, err error) {
+	if result != nil {
+		m.lastResult = result
+		m.lastResultName = name
+	}
+
+	if err == nil && result.Success {
+		m.logViewer.AddLog(ui.LogEntry{
+			Timestamp: time.Now(),
+			Level:     "INFO",
+			Component: "commands",
+			Message:   fmt.Sprintf("Executed: %s - %s", name, m.resultOutput(result)),
+		})
+		m.logStderr("commands", name, result)
+		return
+	}
+
+	if result != nil && result.Cancelled {
+		m.logViewer.AddLog(ui.LogEntry{
+			Timestamp: time.Now(),
+			Level:     "WARN",
+			Component: "commands",
+			Message:   fmt.Sprintf("%s cancelled by user", name),
+		})
+		return
+	}
+
+	errorMsg := "Unknown error"
+	if err != nil {
+		errorMsg = err.Error()
+	} else if !result.Success {
+		errorMsg = result.Error
+	}
+
+	m.logViewer.AddLog(ui.LogEntry{
+		Timestamp: time.Now(),
+		Level:     "ERROR",
+		Component: "commands",
+		Message:   fmt.Sprintf("Failed to execute %s: %s", name, errorMsg),
+	})
+}
+
+// previewSelectedCommand resolves what the selected command would run and logs it
+func (m *MCFModel) previewSelectedCommand() {
+	selectedCommand := m.commandsList.GetSelectedItem()
+	if selectedCommand == nil || m.mcfAdapter == nil {
+		return
+	}
+
+	invocation, err := m.mcfAdapter.PreviewCommand(selectedCommand.Title, nil)
+	if err != nil {
+		m.logViewer.AddLog(ui.LogEntry{
+			Timestamp: time.Now(),
+			Level:     "ERROR",
+			Component: "commands",
+			Message:   fmt.Sprintf("Failed to preview %s: %s", selectedCommand.Title, err),
+		})
+		return
+	}
+
+	m.preview = &invocation
+	m.previewName = selectedCommand.Title
+	m.logViewer.AddLog(ui.LogEntry{
+		Timestamp: time.Now(),
+		Level:     "INFO",
+		Component: "commands",
+		Message:   fmt.Sprintf("Preview %s: %s", selectedCommand.Title, invocation.CommandLine()),
+	})
+}
+
+// queuedPlaybook builds a playbook from the commands marked in the commands list
+func (m *MCFModel) queuedPlaybook(name string) mcf.Playbook {
+	pb := mcf.Playbook{Name: name, ContinueOnError: m.continueOnError}
+	for _, title := range m.commandsList.MarkedTitles() {
+		pb.Steps = append(pb.Steps, mcf.PlaybookStep{Command: title})
+	}
+	return pb
+}
+
+// runPlaybook starts pb in the background unless another playbook is still running.
+// Step results arrive as playbookStepMsg and are shown in the commands view.
+func (m *MCFModel) runPlaybook(pb mcf.Playbook) tea.Cmd {
+	if m.mcfAdapter == nil {
+		m.logViewer.AddLog(ui.LogEntry{
+			Timestamp: time.Now(),
+			Level:     "WARN",
+			Component: "commands",
+			Message:   "⚠ Playbook " + pb.Name + ": MCF adapter not available",
+		})
+		return nil
+	}
+	if m.playbook != nil {
+		m.logViewer.AddLog(ui.LogEntry{
+			Timestamp: time.Now(),
+			Level:     "WARN",
+			Component: "commands",
+			Message:   fmt.Sprintf("Playbook %s is still running", m.playbook.name),
+		})
+		return nil
+	}
+
+	m.playbookResults = nil
+	m.playbook = startPlaybookRun(m.mcfAdapter, pb)
+	return tea.Batch(m.spinner.Tick, m.playbook.next())
+}
+
+// cancelPlaybook stops the running playbook, logging the request once
+func (m *MCFModel) cancelPlaybook() {
+	if m.playbook.cancelled {
+		return
+	}
+	m.playbook.Cancel()
+	m.logViewer.AddLog(ui.LogEntry{
+		Timestamp: time.Now(),
+		Level:     "WARN",
+		Component: "playbook",
+		Message:   "Cancelling playbook " + m.playbook.name,
+	})
+}
+
+// recordPlaybookStep keeps a finished step's result for display and logs its outcome
+func (m *MCFModel) recordPlaybookStep(r mcf.StepResult) {
+	m.playbookResults = append(m.playbookResults, r)
+	i, total := len(m.playbookResults), m.playbook.total
+
+	entry := ui.LogEntry{
+		Timestamp: time.Now(),
+		Level:     "INFO",
+		Component: "playbook",
+		Message:   fmt.Sprintf("[%d/%d] %s: ok", i, total, r.Step.Command),
+	}
+	switch {
+	case r.Skipped && m.playbook.cancelled:
+		entry.Level = "WARN"
+		entry.Message = fmt.Sprintf("[%d/%d] %s: skipped after cancel", i, total, r.Step.Command)
+	case r.Skipped:
+		entry.Level = "WARN"
+		entry.Message = fmt.Sprintf("[%d/%d] %s: skipped after earlier failure", i, total, r.Step.Command)
+	case !r.Succeeded():
+		errorMsg := "Unknown error"
+		if r.Err != nil {
+			errorMsg = r.Err.Error()
+		} else if r.Result != nil {
+			errorMsg = r.Result.Error
+		}
+		entry.Level = "ERROR"
+		entry.Message = fmt.Sprintf("[%d/%d] %s failed: %s", i, total, r.Step.Command, errorMsg)
+	}
+	m.logViewer.AddLog(entry)
+}
+
 // Logs view updates
 func (m MCFModel) updateLogs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -414,7 +890,22 @@ func (m MCFModel) updateLogs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // Config view updates
 func (m MCFModel) updateConfig(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The settings file view is read-only: only scrolling, searching and closing are handled
+	if m.showSettingsFile {
+		if msg.String() == "s" && !m.settingsViewer.Searching() {
+			m.showSettingsFile = false
+			return m, nil
+		}
+		return m, m.settingsViewer.Update(msg)
+	}
+
 	switch msg.String() {
+	case "s":
+		// Show settings file read-only
+		m.showSettingsFile = true
+		m.settingsViewer.SetSize(m.width-6, m.height-8)
+		m.settingsViewer.SetContent(m.settingsFileText())
+
 	case "e":
 		// Edit configuration
 		m.logViewer.AddLog(ui.LogEntry{
@@ -463,6 +954,28 @@ func (m MCFModel) updateCommandBar(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		// Execute command
 		command := m.commandInput.GetValue()
+		if name, ok := strings.CutPrefix(command, "playbook "); ok {
+			// Replay a saved playbook and show its results in the commands view
+			m.commandInput.AddToHistory(command)
+			m.commandInput.Clear()
+			if m.mcfAdapter != nil {
+				pb, err := m.mcfAdapter.LoadPlaybook(strings.TrimSpace(name))
+				if err != nil {
+					m.logViewer.AddLog(ui.LogEntry{
+						Timestamp: time.Now(),
+						Level:     "ERROR",
+						Component: "commands",
+						Message:   "Failed to load playbook: " + err.Error(),
+					})
+					m.SetView(ui.DashboardView)
+					cmd = m.resumeHealthPoll()
+					return m, cmd
+				}
+				cmd = m.runPlaybook(pb)
+			}
+			m.SetView(ui.CommandsView)
+			return m, cmd
+		}
 		if command != "" {
 			m.commandInput.AddToHistory(command)
 
@@ -493,6 +1006,7 @@ func (m MCFModel) updateCommandBar(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 			// Return to dashboard after execution
 			m.SetView(ui.DashboardView)
+			cmd = m.resumeHealthPoll()
 		}
 
 	default:
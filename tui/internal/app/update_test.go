@@ -6,7 +6,9 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"mcf-dev/tui/internal/mcf"
 	testutils "mcf-dev/tui/internal/testing"
 	"mcf-dev/tui/internal/ui"
 )
@@ -243,6 +245,25 @@ func TestMCFModelUpdate_DashboardView(t *testing.T) {
 			assert.NotNil(t, updatedModel, "Should handle quick action shortcut %s", key)
 		}
 	})
+
+	t.Run("should surface Claude connection results", func(t *testing.T) {
+		model := InitialModel()
+		model.ready = true
+		model.width = 120
+		model.height = 36
+		model.SetView(ui.DashboardView)
+
+		newModel, _ := model.Update(claudeConnectionMsg(mcf.ClaudeConnection{
+			Status:      mcf.ClaudeUnauthenticated,
+			Detail:      "401 Unauthorized",
+			Remediation: "Run `claude /login` or replace the stored API key.",
+			CheckedAt:   time.Now(),
+		}))
+		updatedModel := newModel.(MCFModel)
+
+		assert.Contains(t, updatedModel.View(), mcf.ClaudeUnauthenticated, "Dashboard should show the Claude status")
+		assert.Contains(t, updatedModel.logViewer.Render(300), "claude /login", "Log should include remediation")
+	})
 }
 
 func TestMCFModelUpdate_AgentsView(t *testing.T) {
@@ -314,6 +335,65 @@ func TestMCFModelUpdate_CommandsView(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("should preview the selected command without running it", func(t *testing.T) {
+		model := InitialModel()
+		model.ready = true
+		model.width = 200
+		model.height = 60
+		model.SetView(ui.CommandsView)
+		selected := model.commandsList.GetSelectedItem()
+		if model.mcfAdapter == nil || selected == nil {
+			t.Skip("No MCF commands available")
+		}
+
+		newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+		model = newModel.(MCFModel)
+
+		require.NotNil(t, model.preview)
+		assert.Equal(t, selected.Title, model.previewName)
+		assert.Nil(t, model.lastResult, "Preview should not execute")
+		assert.Contains(t, model.View(), "Will Run")
+	})
+}
+
+func TestMCFModelUpdate_LastOutputFormatting(t *testing.T) {
+	t.Run("should cycle raw, pretty and collapsed JSON output", func(t *testing.T) {
+		model := InitialModel()
+		model.ready = true
+		model.width = 200
+		model.height = 60
+		model.SetView(ui.CommandsView)
+		model.lastResult = &mcf.CommandResult{Success: true, Output: `{"status":"ok","agents":["a","b"]}`}
+		model.lastResultName = "agent:status"
+
+		assert.Contains(t, model.renderLastOutput(), `{"status":"ok"`, "Raw output by default")
+
+		p := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")}
+		newModel, _ := model.Update(p)
+		model = newModel.(MCFModel)
+		pretty := testutils.StripANSI(model.renderLastOutput())
+		assert.Contains(t, pretty, "(pretty JSON)")
+		assert.Contains(t, pretty, `  "status": "ok",`)
+
+		newModel, _ = model.Update(p)
+		model = newModel.(MCFModel)
+		assert.Contains(t, testutils.StripANSI(model.renderLastOutput()), `"agents": […2 items]`)
+
+		newModel, _ = model.Update(p)
+		model = newModel.(MCFModel)
+		assert.Contains(t, model.renderLastOutput(), "(raw)")
+	})
+
+	t.Run("should show non-JSON output as raw in every mode", func(t *testing.T) {
+		model := InitialModel()
+		model.lastResult = &mcf.CommandResult{Success: true, Output: "plain text"}
+		model.outputMode = outputPretty
+
+		rendered := model.renderLastOutput()
+		assert.Contains(t, rendered, "plain text")
+		assert.Contains(t, rendered, "(raw)")
+	})
 }
 
 func TestMCFModelUpdate_LogsView(t *testing.T) {
@@ -380,6 +460,45 @@ func TestMCFModelUpdate_ConfigView(t *testing.T) {
 	})
 }
 
+func TestMCFModelUpdate_SettingsFileView(t *testing.T) {
+	t.Run("should open settings file read-only and ignore edit keys", func(t *testing.T) {
+		model := InitialModel()
+		model.ready = true
+		model.width = 100
+		model.height = 30
+		model.SetView(ui.ConfigView)
+
+		newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+		model = newModel.(MCFModel)
+		assert.True(t, model.showSettingsFile, "Should show settings file")
+
+		for _, key := range []string{"e", "d", "b"} {
+			newModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+			model = newModel.(MCFModel)
+		}
+		assert.True(t, model.showSettingsFile, "Edit keys should not leave read-only view")
+
+		newModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+		model = newModel.(MCFModel)
+		assert.Equal(t, 1, model.settingsViewer.YOffset(), "Should scroll down")
+
+		for _, key := range []string{"/", "h", "o", "o", "k", "s"} {
+			newModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+			model = newModel.(MCFModel)
+		}
+		newModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		model = newModel.(MCFModel)
+		assert.True(t, model.showSettingsFile, "Typing s in the search box should not close the view")
+		assert.Contains(t, model.View(), `for "hooks"`)
+
+		assert.Contains(t, model.View(), "read-only")
+
+		newModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+		model = newModel.(MCFModel)
+		assert.False(t, model.showSettingsFile, "Should close settings file")
+	})
+}
+
 func TestMCFModelUpdate_CommandBarView(t *testing.T) {
 	t.Run("should handle command bar interactions", func(t *testing.T) {
 		model := InitialModel()
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BundleVersion is the schema version written to exported bundles; imports
+// refuse any other version
+const BundleVersion = 1
+
+// RedactedValue replaces secrets in bundles exported without secrets.
+// Importing a bundle skips these placeholders rather than storing them.
+const RedactedValue = "<redacted>"
+
+// ErrBundleVersion is returned by ImportConfig for bundles of another schema version
+var ErrBundleVersion = errors.New("unsupported config bundle version")
+
+// secretKeyPattern matches config keys whose values are credentials
+var secretKeyPattern = regexp.MustCompile(`(?i)(api_?key|token|secret|password)`)
+
+// Bundle is a portable snapshot of the merged configuration, for sharing settings across a team
+type Bundle struct {
+	Version  int                    `yaml:"version"`
+	Exported time.Time              `yaml:"exported"`
+	Config   map[string]interface{} `yaml:"config"`
+}
+
+// ExportConfig writes the effective configuration to path as a YAML bundle.
+// Secret values (API keys, tokens, passwords) are redacted unless includeSecrets is set.
+func (l *LayeredConfig) ExportConfig(path string, includeSecrets bool) error {
+	bundle := Bundle{
+		Version:  BundleVersion,
+		Exported: time.Now().UTC().Truncate(time.Second),
+		Config:   l.Effective(),
+	}
+	if !includeSecrets {
+		redactSecrets(bundle.Config)
+	}
+
+	data, err := yaml.Marshal(bundle)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data, configFileMode)
+}
+
+// ImportConfig merges a bundle written by ExportConfig into one layer and saves it.
+// The bundle's version must match BundleVersion, and the configuration it would
+// produce must validate; otherwise nothing is written. Redacted secrets are skipped,
+// so importing a shared bundle keeps the secrets already configured.
+func (l *LayeredConfig) ImportConfig(path string, layer Layer) error {
+	manager, err := l.Layer(layer)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var bundle Bundle
+	if err := yaml.Unmarshal(data, &bundle); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if bundle.Version != BundleVersion {
+		return fmt.Errorf("%w %d (expected %d)", ErrBundleVersion, bundle.Version, BundleVersion)
+	}
+	dropRedacted(bundle.Config)
+
+	effective := l.Effective()
+	mergeConfig(effective, bundle.Config)
+	candidate := &ConfigManager{config: effective}
+	if errs := candidate.Validate(); len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+	}
+
+	mergeConfig(manager.config, bundle.Config)
+	return manager.Save()
+}
+
+// redactSecrets replaces secret values in config, recursing into nested maps
+func redactSecrets(config map[string]interface{}) {
+	for key, value := range config {
+		if nested, ok := value.(map[string]interface{}); ok {
+			redactSecrets(nested)
+			continue
+		}
+		if s, ok := value.(string); ok && s != "" && secretKeyPattern.MatchString(key) {
+			config[key] = RedactedValue
+		}
+	}
+}
+
+// dropRedacted removes redaction placeholders from config, recursing into nested maps
+func dropRedacted(config map[string]interface{}) {
+	for key, value := range config {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			dropRedacted(v)
+		case string:
+			if v == RedactedValue {
+				delete(config, key)
+			}
+		}
+	}
+}
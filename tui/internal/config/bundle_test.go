@@ -0,0 +1,113 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestConfigBundle(t *testing.T) {
+	newLayered := func(t *testing.T) (*LayeredConfig, map[Layer]string) {
+		paths := DefaultLayerPaths(t.TempDir(), t.TempDir())
+		writeLayer(t, paths[LayerGlobal], `{"mcf": {"host": "global-host", "port": 8080, "claude_api_key": "sk-global"}, "tui": {"theme": "dark"}}`)
+		writeLayer(t, paths[LayerProject], `{"tui": {"theme": "light"}, "features": {"serena": true}}`)
+
+		layered := NewLayeredConfig(paths, nil)
+		require.NoError(t, layered.Load())
+		return layered, paths
+	}
+
+	readBundle := func(t *testing.T, path string) Bundle {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var bundle Bundle
+		require.NoError(t, yaml.Unmarshal(data, &bundle))
+		return bundle
+	}
+
+	t.Run("should export the merged config with secrets redacted", func(t *testing.T) {
+		layered, _ := newLayered(t)
+		path := filepath.Join(t.TempDir(), "team.yaml")
+
+		require.NoError(t, layered.ExportConfig(path, false))
+
+		bundle := readBundle(t, path)
+		assert.Equal(t, BundleVersion, bundle.Version)
+		assert.False(t, bundle.Exported.IsZero())
+		mcf := bundle.Config["mcf"].(map[string]interface{})
+		assert.Equal(t, "global-host", mcf["host"])
+		assert.Equal(t, RedactedValue, mcf["claude_api_key"])
+		assert.Equal(t, "light", bundle.Config["tui"].(map[string]interface{})["theme"], "Higher layers should win")
+		assert.Equal(t, true, bundle.Config["features"].(map[string]interface{})["serena"])
+
+		value, _, _ := layered.Get("mcf.claude_api_key")
+		assert.Equal(t, "sk-global", value, "Redaction should not touch the loaded layers")
+	})
+
+	t.Run("should keep secrets when asked", func(t *testing.T) {
+		layered, _ := newLayered(t)
+		path := filepath.Join(t.TempDir(), "team.yaml")
+
+		require.NoError(t, layered.ExportConfig(path, true))
+
+		mcf := readBundle(t, path).Config["mcf"].(map[string]interface{})
+		assert.Equal(t, "sk-global", mcf["claude_api_key"])
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, configFileMode, info.Mode().Perm())
+	})
+
+	t.Run("should import into one layer without clobbering secrets", func(t *testing.T) {
+		source, _ := newLayered(t)
+		path := filepath.Join(t.TempDir(), "team.yaml")
+		require.NoError(t, source.ExportConfig(path, false))
+
+		target, paths := newLayered(t)
+		require.NoError(t, target.Set(LayerGlobal, "mcf.claude_api_key", "sk-mine"))
+		require.NoError(t, target.Set(LayerGlobal, "tui.theme", "solarized"))
+		require.NoError(t, target.Set(LayerProject, "tui.theme", "dark"))
+		require.NoError(t, target.ImportConfig(path, LayerLocal))
+
+		reloaded := NewLayeredConfig(paths, nil)
+		require.NoError(t, reloaded.Load())
+		theme, layer, _ := reloaded.Get("tui.theme")
+		assert.Equal(t, "light", theme)
+		assert.Equal(t, LayerLocal, layer)
+		key, layer, _ := reloaded.Get("mcf.claude_api_key")
+		assert.Equal(t, "sk-mine", key, "Redacted placeholders should not be imported")
+		assert.Equal(t, LayerGlobal, layer)
+	})
+
+	t.Run("should reject other schema versions", func(t *testing.T) {
+		layered, paths := newLayered(t)
+		path := filepath.Join(t.TempDir(), "future.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("version: 2\nconfig:\n  tui:\n    theme: neon\n"), 0600))
+
+		err := layered.ImportConfig(path, LayerProject)
+
+		assert.True(t, errors.Is(err, ErrBundleVersion))
+		data, readErr := os.ReadFile(paths[LayerProject])
+		require.NoError(t, readErr)
+		assert.NotContains(t, string(data), "neon")
+	})
+
+	t.Run("should validate before writing", func(t *testing.T) {
+		layered, paths := newLayered(t)
+		path := filepath.Join(t.TempDir(), "bad.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("version: 1\nconfig:\n  mcf:\n    port: 70000\n"), 0600))
+
+		err := layered.ImportConfig(path, LayerProject)
+
+		assert.True(t, errors.Is(err, ErrInvalidConfig))
+		assert.Contains(t, err.Error(), "mcf.port")
+		data, readErr := os.ReadFile(paths[LayerProject])
+		require.NoError(t, readErr)
+		assert.NotContains(t, string(data), "70000")
+	})
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ApplyEnvOverrides layers environment variables over the loaded configuration.
+// Each leaf key maps to prefix + the key upper-cased with dots as underscores, so
+// with prefix "MCF" the variable MCF_MCF_HOST overrides mcf.host and
+// MCF_TUI_THEME overrides tui.theme. Values are coerced to the type of the
+// default, or of the file's value for keys outside the schema (string, int,
+// float or bool).
+//
+// Precedence is environment over file over DefaultConfig. Overrides are held
+// apart from the file contents: Get sees them, but Save never writes them, and
+// they survive a later Load. A value that does not coerce is skipped and
+// reported in the returned error; the remaining overrides still apply.
+func (c *ConfigManager) ApplyEnvOverrides(prefix string) error {
+	if prefix != "" && !strings.HasSuffix(prefix, "_") {
+		prefix += "_"
+	}
+
+	keys := leafKeys(DefaultConfig, "")
+	keys = append(keys, leafKeys(c.config, "")...)
+	sort.Strings(keys)
+
+	var errs []error
+	applied := make(map[string]bool)
+	for _, key := range keys {
+		if applied[key] {
+			continue
+		}
+		applied[key] = true
+
+		name := prefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+		raw, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		// Prefer the schema's type: JSON files decode every number as float64
+		current, _ := c.getNestedValue(DefaultConfig, key)
+		if current == nil {
+			current, _ = c.getNestedValue(c.config, key)
+		}
+		value, err := coerceEnvValue(raw, current)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+
+		if c.overrides == nil {
+			c.overrides = make(map[string]interface{})
+		}
+		c.overrides[key] = value
+		if c.logger != nil {
+			c.logger.Log("Config key %s overridden by %s", key, name)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ApplyEnvOverrides layers environment variables over every file layer, mapping
+// and coercing them as ConfigManager.ApplyEnvOverrides does. Precedence is
+// environment over local over project over global over DefaultConfig; Get reports
+// overridden keys from LayerEnv. Overrides survive Load and are never saved.
+func (l *LayeredConfig) ApplyEnvOverrides(prefix string) error {
+	// The merged files supply the types of keys outside the schema
+	env := &ConfigManager{config: l.Effective()}
+	err := env.ApplyEnvOverrides(prefix)
+	env.config = make(map[string]interface{})
+	l.env = env
+	return err
+}
+
+// Overrides returns the keys currently set from the environment
+func (c *ConfigManager) Overrides() []string {
+	keys := make([]string, 0, len(c.overrides))
+	for key := range c.overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// coerceEnvValue parses raw as the same type as current
+func coerceEnvValue(raw string, current interface{}) (interface{}, error) {
+	switch current.(type) {
+	case bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", raw)
+		}
+		return value, nil
+	case int, int64:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", raw)
+		}
+		return value, nil
+	case float64:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", raw)
+		}
+		return value, nil
+	case string:
+		return raw, nil
+	default:
+		return nil, fmt.Errorf("cannot override a %T value", current)
+	}
+}
+
+// leafKeys lists the dot-notation keys of every non-map value in config
+func leafKeys(config map[string]interface{}, prefix string) []string {
+	var keys []string
+	for key, value := range config {
+		if nested, ok := value.(map[string]interface{}); ok {
+			keys = append(keys, leafKeys(nested, prefix+key+".")...)
+			continue
+		}
+		keys = append(keys, prefix+key)
+	}
+	return keys
+}
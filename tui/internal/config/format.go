@@ -0,0 +1,41 @@
+package config
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// isYAMLPath reports whether path names a YAML config, by its extension
+func isYAMLPath(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// encode serializes config in the backing file's format: YAML for .yaml/.yml, JSON otherwise
+func (c *ConfigManager) encode(config map[string]interface{}) ([]byte, error) {
+	if isYAMLPath(c.configPath) {
+		return yaml.Marshal(config)
+	}
+	return json.MarshalIndent(config, "", "  ")
+}
+
+// decode parses data in the backing file's format into config
+func (c *ConfigManager) decode(data []byte, config *map[string]interface{}) error {
+	if isYAMLPath(c.configPath) {
+		if err := yaml.Unmarshal(data, config); err != nil {
+			return err
+		}
+		if *config == nil {
+			// An empty YAML document decodes to nothing
+			*config = make(map[string]interface{})
+		}
+		return nil
+	}
+	return json.Unmarshal(data, config)
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Layer names one config file in the global < project < local precedence chain
+type Layer string
+
+const (
+	LayerGlobal  Layer = "global"
+	LayerProject Layer = "project"
+	LayerLocal   Layer = "local"
+
+	// LayerEnv reports values set by LayeredConfig.ApplyEnvOverrides. It has no
+	// file, so it cannot be edited through Layer.
+	LayerEnv Layer = "env"
+)
+
+// layerOrder lists layers from lowest to highest precedence
+var layerOrder = []Layer{LayerGlobal, LayerProject, LayerLocal}
+
+// DefaultLayerPaths returns the conventional file for each layer: the user's
+// global config, a shared project config, and an uncommitted local override
+func DefaultLayerPaths(homeDir, projectRoot string) map[Layer]string {
+	return map[Layer]string{
+		LayerGlobal:  filepath.Join(homeDir, ".config", "mcf-tui", "config.json"),
+		LayerProject: filepath.Join(projectRoot, ".claude", "mcf-tui.json"),
+		LayerLocal:   filepath.Join(projectRoot, ".claude", "mcf-tui.local.json"),
+	}
+}
+
+// LayerError reports which layer failed to load
+type LayerError struct {
+	Layer Layer
+	Err   error
+}
+
+func (e *LayerError) Error() string {
+	return fmt.Sprintf("%s config: %v", e.Layer, e.Err)
+}
+
+func (e *LayerError) Unwrap() error {
+	return e.Err
+}
+
+// LayeredConfig resolves values local over project over global. Each layer is
+// a ConfigManager backed by its own file, so edits only touch that layer.
+type LayeredConfig struct {
+	layers map[Layer]*ConfigManager
+	env    *ConfigManager // environment overrides only, nil until ApplyEnvOverrides
+}
+
+// NewLayeredConfig creates a layered config from one file path per layer
+func NewLayeredConfig(paths map[Layer]string, logger Logger) *LayeredConfig {
+	layers := make(map[Layer]*ConfigManager, len(layerOrder))
+	for _, layer := range layerOrder {
+		layers[layer] = NewConfigManager(paths[layer], logger)
+	}
+	return &LayeredConfig{layers: layers}
+}
+
+// Load reads every layer. The global layer is reconciled with (or created from)
+// the defaults like a plain ConfigManager; project and local layers hold only
+// what their files set, and a missing file is an empty layer. A layer that fails
+// to load is reported as a *LayerError.
+func (l *LayeredConfig) Load() error {
+	for _, layer := range layerOrder {
+		manager := l.layers[layer]
+		if layer == LayerGlobal {
+			if err := manager.Load(); err != nil {
+				return &LayerError{Layer: layer, Err: err}
+			}
+			continue
+		}
+
+		if _, err := os.Stat(manager.configPath); os.IsNotExist(err) {
+			manager.config = make(map[string]interface{})
+			continue
+		}
+		if err := manager.load(false); err != nil {
+			return &LayerError{Layer: layer, Err: err}
+		}
+	}
+	return nil
+}
+
+// Layer returns the manager for a single layer, for viewing or editing it alone
+func (l *LayeredConfig) Layer(layer Layer) (*ConfigManager, error) {
+	manager, ok := l.layers[layer]
+	if !ok {
+		return nil, fmt.Errorf("unknown config layer %q", layer)
+	}
+	return manager, nil
+}
+
+// Get returns the effective value for key and the layer that supplies it
+func (l *LayeredConfig) Get(key string) (interface{}, Layer, bool) {
+	if l.env != nil {
+		if value, ok := l.env.Get(key); ok {
+			return value, LayerEnv, true
+		}
+	}
+	for i := len(layerOrder) - 1; i >= 0; i-- {
+		layer := layerOrder[i]
+		if value, ok := l.layers[layer].Get(key); ok {
+			return value, layer, true
+		}
+	}
+	return nil, "", false
+}
+
+// Set writes key to one layer and saves only that layer's file
+func (l *LayeredConfig) Set(layer Layer, key string, value interface{}) error {
+	manager, err := l.Layer(layer)
+	if err != nil {
+		return err
+	}
+	return manager.Set(key, value)
+}
+
+// ApplyRaw replaces one layer with edited text and saves it. The global layer is
+// reconciled with the defaults like ConfigManager.ApplyRaw; project and local
+// layers keep only what the text sets, and the configuration the edit would
+// produce must validate. On any problem nothing is written and the error wraps
+// ErrInvalidConfig.
+func (l *LayeredConfig) ApplyRaw(layer Layer, text string) error {
+	manager, err := l.Layer(layer)
+	if err != nil {
+		return err
+	}
+	if layer == LayerGlobal {
+		return manager.ApplyRaw(text)
+	}
+
+	parsed, err := manager.parseRaw(text)
+	if err != nil {
+		return err
+	}
+
+	effective := make(map[string]interface{})
+	for _, other := range layerOrder {
+		if other == layer {
+			mergeConfig(effective, parsed)
+		} else {
+			mergeConfig(effective, l.layers[other].config)
+		}
+	}
+	candidate := &ConfigManager{config: effective}
+	if errs := candidate.Validate(); len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+	}
+
+	manager.config = parsed
+	return manager.Save()
+}
+
+// Effective returns the merged configuration with higher layers overriding lower
+// ones. Environment overrides are left out, so exports only carry file values.
+func (l *LayeredConfig) Effective() map[string]interface{} {
+	merged := make(map[string]interface{})
+	for _, layer := range layerOrder {
+		mergeConfig(merged, l.layers[layer].config)
+	}
+	return merged
+}
+
+// mergeConfig deep-merges src into dst, copying nested maps so layers stay independent
+func mergeConfig(dst, src map[string]interface{}) {
+	for key, value := range src {
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			dst[key] = value
+			continue
+		}
+		target, ok := dst[key].(map[string]interface{})
+		if !ok {
+			target = make(map[string]interface{})
+			dst[key] = target
+		}
+		mergeConfig(target, nested)
+	}
+}
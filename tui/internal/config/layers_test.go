@@ -0,0 +1,169 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeLayer writes a layer file with the given JSON content
+func writeLayer(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestLayeredConfig(t *testing.T) {
+	newLayered := func(t *testing.T) (*LayeredConfig, map[Layer]string) {
+		paths := DefaultLayerPaths(t.TempDir(), t.TempDir())
+		writeLayer(t, paths[LayerGlobal], `{"mcf": {"host": "global-host", "port": 8080}, "tui": {"theme": "dark"}}`)
+		writeLayer(t, paths[LayerProject], `{"mcf": {"host": "project-host"}, "tui": {"theme": "light"}}`)
+		writeLayer(t, paths[LayerLocal], `{"mcf": {"host": "local-host"}}`)
+
+		layered := NewLayeredConfig(paths, nil)
+		require.NoError(t, layered.Load())
+		return layered, paths
+	}
+
+	t.Run("should resolve local over project over global", func(t *testing.T) {
+		layered, _ := newLayered(t)
+
+		tests := []struct {
+			key   string
+			value interface{}
+			layer Layer
+		}{
+			{"mcf.host", "local-host", LayerLocal},
+			{"tui.theme", "light", LayerProject},
+			{"mcf.port", float64(8080), LayerGlobal},
+		}
+		for _, tt := range tests {
+			value, layer, ok := layered.Get(tt.key)
+			assert.True(t, ok, tt.key)
+			assert.Equal(t, tt.value, value, tt.key)
+			assert.Equal(t, tt.layer, layer, tt.key)
+		}
+
+		_, _, ok := layered.Get("mcf.missing")
+		assert.False(t, ok)
+	})
+
+	t.Run("should merge layers into the effective config", func(t *testing.T) {
+		layered, _ := newLayered(t)
+
+		effective := layered.Effective()
+
+		mcfSection := effective["mcf"].(map[string]interface{})
+		assert.Equal(t, "local-host", mcfSection["host"])
+		assert.Equal(t, float64(8080), mcfSection["port"])
+		assert.Equal(t, "light", effective["tui"].(map[string]interface{})["theme"])
+		assert.Equal(t, "info", effective["logging"].(map[string]interface{})["level"], "Global layer supplies defaults")
+
+		project, err := layered.Layer(LayerProject)
+		require.NoError(t, err)
+		host, err := project.GetString("mcf.host")
+		require.NoError(t, err)
+		assert.Equal(t, "project-host", host, "Merging should not modify layers")
+	})
+
+	t.Run("should save only the edited layer", func(t *testing.T) {
+		layered, paths := newLayered(t)
+		before := map[Layer][]byte{}
+		for _, layer := range []Layer{LayerGlobal, LayerLocal} {
+			data, err := os.ReadFile(paths[layer])
+			require.NoError(t, err)
+			before[layer] = data
+		}
+
+		require.NoError(t, layered.Set(LayerProject, "mcf.timeout", 60))
+
+		reloaded := NewLayeredConfig(paths, nil)
+		require.NoError(t, reloaded.Load())
+		value, layer, ok := reloaded.Get("mcf.timeout")
+		assert.True(t, ok)
+		assert.Equal(t, float64(60), value)
+		assert.Equal(t, LayerProject, layer)
+
+		for l, data := range before {
+			after, err := os.ReadFile(paths[l])
+			require.NoError(t, err)
+			assert.Equal(t, string(data), string(after), "%s layer should be untouched", l)
+		}
+	})
+
+	t.Run("should treat missing project and local files as empty", func(t *testing.T) {
+		paths := DefaultLayerPaths(t.TempDir(), t.TempDir())
+		writeLayer(t, paths[LayerGlobal], `{"mcf": {"host": "global-host"}}`)
+
+		layered := NewLayeredConfig(paths, nil)
+		require.NoError(t, layered.Load())
+
+		value, layer, ok := layered.Get("mcf.host")
+		assert.True(t, ok)
+		assert.Equal(t, "global-host", value)
+		assert.Equal(t, LayerGlobal, layer)
+		assert.NoFileExists(t, paths[LayerLocal])
+	})
+
+	t.Run("should name the layer that fails to load", func(t *testing.T) {
+		paths := DefaultLayerPaths(t.TempDir(), t.TempDir())
+		writeLayer(t, paths[LayerLocal], "{")
+
+		err := NewLayeredConfig(paths, nil).Load()
+
+		var layerErr *LayerError
+		require.ErrorAs(t, err, &layerErr)
+		assert.Equal(t, LayerLocal, layerErr.Layer)
+		assert.ErrorIs(t, err, ErrCorruptConfig)
+	})
+
+	t.Run("should apply a raw edit to one layer only", func(t *testing.T) {
+		layered, paths := newLayered(t)
+
+		require.NoError(t, layered.ApplyRaw(LayerProject, `{"mcf": {"port": 9090}}`))
+
+		data, err := os.ReadFile(paths[LayerProject])
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"mcf": {"port": 9090}}`, string(data), "Project layer should not gain defaults")
+		value, layer, _ := layered.Get("mcf.port")
+		assert.Equal(t, float64(9090), value)
+		assert.Equal(t, LayerProject, layer)
+
+		err = layered.ApplyRaw(LayerLocal, `{"mcf": {"port": 70000}}`)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		assert.Contains(t, err.Error(), "mcf.port must be between")
+		data, err = os.ReadFile(paths[LayerLocal])
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"mcf": {"host": "local-host"}}`, string(data))
+	})
+
+	t.Run("should let the environment override every layer", func(t *testing.T) {
+		layered, _ := newLayered(t)
+		t.Setenv("MCF_MCF_HOST", "env-host")
+		t.Setenv("MCF_MCF_PORT", "not-a-port")
+
+		err := layered.ApplyEnvOverrides("MCF")
+
+		assert.ErrorContains(t, err, "MCF_MCF_PORT")
+		value, layer, _ := layered.Get("mcf.host")
+		assert.Equal(t, "env-host", value)
+		assert.Equal(t, LayerEnv, layer)
+		value, layer, _ = layered.Get("mcf.port")
+		assert.Equal(t, float64(8080), value, "A value that fails to coerce is skipped")
+		assert.Equal(t, LayerGlobal, layer)
+		assert.Equal(t, "local-host", layered.Effective()["mcf"].(map[string]interface{})["host"])
+
+		require.NoError(t, layered.Load())
+		value, _, _ = layered.Get("mcf.host")
+		assert.Equal(t, "env-host", value, "Overrides should survive a reload")
+	})
+
+	t.Run("should reject unknown layers", func(t *testing.T) {
+		layered, _ := newLayered(t)
+
+		assert.Error(t, layered.Set(Layer("team"), "mcf.host", "x"))
+	})
+}
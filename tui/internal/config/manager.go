@@ -0,0 +1,669 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConfigManager handles configuration management for the TUI application
+type ConfigManager struct {
+	configPath string
+	config     map[string]interface{}
+	logger     Logger
+	writeFile  func(path string, data []byte, perm os.FileMode) error
+
+	// Set by Load when the file and DefaultConfig disagree
+	defaultedKeys []string // schema keys missing from the file, filled from defaults
+	unmanagedKeys []string // file keys the schema doesn't know, kept and saved as-is
+
+	overrides map[string]interface{} // dot-notation keys set by ApplyEnvOverrides, never saved
+}
+
+// configFileMode keeps config files owner-only since they may hold API keys
+const configFileMode os.FileMode = 0600
+
+// maxSaveBackups is how many timestamped .bak files Save keeps next to the config
+const maxSaveBackups = 5
+
+// ErrCorruptConfig is returned by Load when the config file cannot be parsed
+var ErrCorruptConfig = errors.New("config file is corrupt")
+
+// ErrInvalidConfig is returned by ApplyRaw when edited text does not parse or validate
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// Logger is the logging surface used by ConfigManager
+type Logger interface {
+	Log(format string, args ...interface{})
+	Error(format string, args ...interface{})
+}
+
+// DefaultConfig represents the default configuration structure
+var DefaultConfig = map[string]interface{}{
+	"mcf": map[string]interface{}{
+		"host":           "localhost",
+		"port":           8080,
+		"timeout":        30,
+		"retry_attempts": 3,
+		"retry_delay":    1000,
+		"tls_enabled":    false,
+		"api_version":    "v1",
+	},
+	"tui": map[string]interface{}{
+		"theme":           "dark",
+		"refresh_rate":    1000,
+		"max_log_lines":   1000,
+		"auto_scroll":     true,
+		"show_timestamps": true,
+		"default_view":    "dashboard",
+	},
+	"logging": map[string]interface{}{
+		"level":     "info",
+		"file_path": "mcf-tui.log",
+		"max_size":  10,
+		"max_age":   7,
+		"max_files": 3,
+		"compress":  true,
+	},
+	"performance": map[string]interface{}{
+		"max_goroutines":     50,
+		"cache_size":         100,
+		"gc_percent":         100,
+		"memory_limit_mb":    512,
+		"cpu_limit_percent":  80.0,
+		"disk_limit_percent": 90.0,
+	},
+}
+
+// NewConfigManager creates a new configuration manager instance
+func NewConfigManager(configPath string, logger Logger) *ConfigManager {
+	return &ConfigManager{
+		configPath: configPath,
+		config:     make(map[string]interface{}),
+		logger:     logger,
+		writeFile:  writeFileAtomic,
+	}
+}
+
+// Load loads configuration from file, filling keys the file lacks from defaults.
+// Files ending in .yaml or .yml are read and written as YAML, anything else as JSON.
+func (c *ConfigManager) Load() error {
+	return c.load(true)
+}
+
+// load reads the config file; withDefaults reconciles it against DefaultConfig.
+// Override layers load without defaults so they only hold what they set.
+func (c *ConfigManager) load(withDefaults bool) error {
+	if c.logger != nil {
+		c.logger.Log("Loading configuration from %s", c.configPath)
+	}
+
+	// Check if config file exists
+	if _, err := os.Stat(c.configPath); os.IsNotExist(err) {
+		if c.logger != nil {
+			c.logger.Log("Config file does not exist, using defaults")
+		}
+		c.config = copyConfig(DefaultConfig)
+		return c.Save() // Create default config file
+	}
+
+	data, err := os.ReadFile(c.configPath)
+	if err != nil {
+		if c.logger != nil {
+			c.logger.Error("Failed to read config file: %v", err)
+		}
+		return err
+	}
+
+	// Decode into a fresh map so keys from a previous load don't linger
+	loaded := make(map[string]interface{})
+	err = c.decode(data, &loaded)
+	if err != nil {
+		if c.logger != nil {
+			c.logger.Error("Failed to unmarshal config: %v", err)
+		}
+		if latest := c.LatestBackup(); latest != "" {
+			return fmt.Errorf("%w: %v (latest backup: %s)", ErrCorruptConfig, err, latest)
+		}
+		return fmt.Errorf("%w: %v", ErrCorruptConfig, err)
+	}
+	c.config = loaded
+
+	if withDefaults {
+		c.reconcile()
+	}
+
+	if c.logger != nil {
+		c.logger.Log("Configuration loaded successfully")
+	}
+	return nil
+}
+
+// reconcile fills schema keys missing from the loaded file with defaults and
+// records file keys the schema doesn't know about. Unknown keys stay in the
+// config untouched so a later Save writes them back instead of dropping them.
+func (c *ConfigManager) reconcile() {
+	if c.config == nil {
+		c.config = make(map[string]interface{})
+	}
+	c.defaultedKeys = fillDefaults(c.config, DefaultConfig, "")
+	c.unmanagedKeys = unknownKeys(c.config, DefaultConfig, "")
+	sort.Strings(c.defaultedKeys)
+	sort.Strings(c.unmanagedKeys)
+
+	if c.logger != nil {
+		if len(c.defaultedKeys) > 0 {
+			c.logger.Log("Config file is missing %d keys; using defaults for %v", len(c.defaultedKeys), c.defaultedKeys)
+		}
+		if len(c.unmanagedKeys) > 0 {
+			c.logger.Log("Config file has %d unmanaged keys, preserved as-is: %v", len(c.unmanagedKeys), c.unmanagedKeys)
+		}
+	}
+}
+
+// DefaultedKeys returns the keys Load filled from defaults because the file lacked them
+func (c *ConfigManager) DefaultedKeys() []string {
+	return c.defaultedKeys
+}
+
+// UnmanagedKeys returns file keys not in the schema; they are read-only here and preserved on save
+func (c *ConfigManager) UnmanagedKeys() []string {
+	return c.unmanagedKeys
+}
+
+// fillDefaults copies defaults missing from config into it, returning the dot keys it filled
+func fillDefaults(config, defaults map[string]interface{}, prefix string) []string {
+	var filled []string
+	for key, def := range defaults {
+		path := prefix + key
+		value, exists := config[key]
+		if !exists {
+			if nested, ok := def.(map[string]interface{}); ok {
+				config[key] = copyConfig(nested)
+			} else {
+				config[key] = def
+			}
+			filled = append(filled, path)
+			continue
+		}
+
+		defNested, defIsMap := def.(map[string]interface{})
+		nested, isMap := value.(map[string]interface{})
+		if defIsMap && isMap {
+			filled = append(filled, fillDefaults(nested, defNested, path+".")...)
+		}
+	}
+	return filled
+}
+
+// unknownKeys returns dot keys present in config but absent from defaults.
+// An unknown section is reported once rather than leaf by leaf.
+func unknownKeys(config, defaults map[string]interface{}, prefix string) []string {
+	var unknown []string
+	for key, value := range config {
+		path := prefix + key
+		def, known := defaults[key]
+		if !known {
+			unknown = append(unknown, path)
+			continue
+		}
+
+		defNested, defIsMap := def.(map[string]interface{})
+		nested, isMap := value.(map[string]interface{})
+		if defIsMap && isMap {
+			unknown = append(unknown, unknownKeys(nested, defNested, path+".")...)
+		}
+	}
+	return unknown
+}
+
+// copyConfig deep-copies a config map so edits never reach DefaultConfig
+func copyConfig(src map[string]interface{}) map[string]interface{} {
+	dst := make(map[string]interface{}, len(src))
+	for key, value := range src {
+		if nested, ok := value.(map[string]interface{}); ok {
+			dst[key] = copyConfig(nested)
+		} else {
+			dst[key] = value
+		}
+	}
+	return dst
+}
+
+// Save saves configuration to file
+func (c *ConfigManager) Save() error {
+	if c.logger != nil {
+		c.logger.Log("Saving configuration to %s", c.configPath)
+	}
+
+	// Create directory if it doesn't exist
+	dir := filepath.Dir(c.configPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		if c.logger != nil {
+			c.logger.Error("Failed to create config directory: %v", err)
+		}
+		return err
+	}
+
+	data, err := c.encode(c.config)
+	if err != nil {
+		if c.logger != nil {
+			c.logger.Error("Failed to marshal config: %v", err)
+		}
+		return err
+	}
+
+	if err := c.backupBeforeSave(); err != nil {
+		if c.logger != nil {
+			c.logger.Error("Failed to back up config before save: %v", err)
+		}
+		return err
+	}
+
+	err = c.writeFile(c.configPath, data, configFileMode)
+	if err != nil {
+		if c.logger != nil {
+			c.logger.Error("Failed to write config file: %v", err)
+		}
+		return err
+	}
+
+	if c.logger != nil {
+		c.logger.Log("Configuration saved successfully")
+	}
+	return nil
+}
+
+// Get retrieves a configuration value using dot notation (e.g., "mcf.host").
+// Environment overrides take precedence over the file.
+func (c *ConfigManager) Get(key string) (interface{}, bool) {
+	if value, ok := c.overrides[key]; ok {
+		return value, true
+	}
+	return c.getNestedValue(c.config, key)
+}
+
+// Set sets a configuration value using dot notation
+func (c *ConfigManager) Set(key string, value interface{}) error {
+	if c.logger != nil {
+		c.logger.Log("Setting config key %s to %v", key, value)
+	}
+
+	err := c.setNestedValue(c.config, key, value)
+	if err == nil {
+		return c.Save()
+	}
+	return err
+}
+
+// SetMany sets several configuration values and saves once.
+// Use it for batched edits; Set keeps saving on every call for the headless CLI.
+func (c *ConfigManager) SetMany(values map[string]interface{}) error {
+	if c.logger != nil {
+		c.logger.Log("Setting %d config keys", len(values))
+	}
+
+	for key, value := range values {
+		if err := c.setNestedValue(c.config, key, value); err != nil {
+			return err
+		}
+	}
+
+	return c.Save()
+}
+
+// GetString retrieves a string configuration value
+func (c *ConfigManager) GetString(key string) (string, error) {
+	value, exists := c.Get(key)
+	if !exists {
+		return "", fmt.Errorf("key %s not found", key)
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %s is not a string", key)
+	}
+
+	return str, nil
+}
+
+// GetInt retrieves an integer configuration value
+func (c *ConfigManager) GetInt(key string) (int, error) {
+	value, exists := c.Get(key)
+	if !exists {
+		return 0, fmt.Errorf("key %s not found", key)
+	}
+
+	// Handle different numeric types from JSON unmarshaling
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case float64:
+		return int(v), nil
+	case int64:
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("key %s is not a number", key)
+	}
+}
+
+// GetBool retrieves a boolean configuration value
+func (c *ConfigManager) GetBool(key string) (bool, error) {
+	value, exists := c.Get(key)
+	if !exists {
+		return false, fmt.Errorf("key %s not found", key)
+	}
+
+	boolean, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("key %s is not a boolean", key)
+	}
+
+	return boolean, nil
+}
+
+// GetFloat retrieves a float configuration value
+func (c *ConfigManager) GetFloat(key string) (float64, error) {
+	value, exists := c.Get(key)
+	if !exists {
+		return 0, fmt.Errorf("key %s not found", key)
+	}
+
+	// Handle different numeric types
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	default:
+		return 0, fmt.Errorf("key %s is not a number", key)
+	}
+}
+
+// Reset resets configuration to defaults
+func (c *ConfigManager) Reset() error {
+	if c.logger != nil {
+		c.logger.Log("Resetting configuration to defaults")
+	}
+
+	c.config = copyConfig(DefaultConfig)
+
+	return c.Save()
+}
+
+// Backup creates a backup of the current configuration
+func (c *ConfigManager) Backup(backupPath string) error {
+	if c.logger != nil {
+		c.logger.Log("Creating backup at %s", backupPath)
+	}
+
+	// Create backup directory if needed
+	dir := filepath.Dir(backupPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := c.encode(c.config)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(backupPath, data, configFileMode)
+}
+
+// Raw returns the current configuration in the file's format for whole-file editing
+func (c *ConfigManager) Raw() (string, error) {
+	data, err := c.encode(c.config)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\n") + "\n", nil
+}
+
+// ApplyRaw replaces the configuration with edited text. The text is parsed and
+// reconciled with DefaultConfig as Load does, so keys it leaves out fall back to
+// their defaults, then validated; on any problem nothing is written and the error
+// wraps ErrInvalidConfig. Save backs up the previous file before writing.
+func (c *ConfigManager) ApplyRaw(text string) error {
+	parsed, err := c.parseRaw(text)
+	if err != nil {
+		return err
+	}
+
+	candidate := &ConfigManager{config: parsed}
+	candidate.reconcile()
+	if errs := candidate.Validate(); len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+	}
+
+	if c.logger != nil {
+		c.logger.Log("Applying raw configuration edit")
+	}
+	c.config = candidate.config
+	c.defaultedKeys = candidate.defaultedKeys
+	c.unmanagedKeys = candidate.unmanagedKeys
+	return c.Save()
+}
+
+// parseRaw decodes edited text in the file's format, wrapping syntax errors in ErrInvalidConfig
+func (c *ConfigManager) parseRaw(text string) (map[string]interface{}, error) {
+	parsed := map[string]interface{}{}
+	if err := c.decode([]byte(text), &parsed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	return parsed, nil
+}
+
+// Path returns the file backing the configuration
+func (c *ConfigManager) Path() string {
+	return c.configPath
+}
+
+// Validate validates the current configuration
+func (c *ConfigManager) Validate() []error {
+	var errors []error
+
+	// Required string fields
+	requiredStrings := map[string]string{
+		"mcf.host":        "MCF host",
+		"mcf.api_version": "API version",
+		"tui.theme":       "TUI theme",
+		"logging.level":   "Log level",
+	}
+
+	for key, description := range requiredStrings {
+		if _, err := c.GetString(key); err != nil {
+			errors = append(errors, fmt.Errorf("%s (%s) is required", description, key))
+		}
+	}
+
+	// Validate numeric ranges
+	port, err := c.GetInt("mcf.port")
+	if err == nil {
+		if port < 1 || port > 65535 {
+			errors = append(errors, fmt.Errorf("mcf.port must be between 1 and 65535"))
+		}
+	}
+
+	timeout, err := c.GetInt("mcf.timeout")
+	if err == nil {
+		if timeout < 1 || timeout > 300 {
+			errors = append(errors, fmt.Errorf("mcf.timeout must be between 1 and 300 seconds"))
+		}
+	}
+
+	return errors
+}
+
+// Helper methods for nested value operations
+func (c *ConfigManager) getNestedValue(config map[string]interface{}, key string) (interface{}, bool) {
+	keys := c.splitKey(key)
+	current := config
+
+	for i, k := range keys {
+		if i == len(keys)-1 {
+			value, exists := current[k]
+			return value, exists
+		}
+
+		next, exists := current[k]
+		if !exists {
+			return nil, false
+		}
+
+		nextMap, ok := next.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		current = nextMap
+	}
+
+	return nil, false
+}
+
+func (c *ConfigManager) setNestedValue(config map[string]interface{}, key string, value interface{}) error {
+	keys := c.splitKey(key)
+	current := config
+
+	for i, k := range keys {
+		if i == len(keys)-1 {
+			current[k] = value
+			return nil
+		}
+
+		next, exists := current[k]
+		if !exists {
+			next = make(map[string]interface{})
+			current[k] = next
+		}
+
+		nextMap, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cannot set nested value: %s is not a map", k)
+		}
+
+		current = nextMap
+	}
+
+	return nil
+}
+
+func (c *ConfigManager) splitKey(key string) []string {
+	// Simple dot notation split
+	result := []string{}
+	current := ""
+
+	for _, char := range key {
+		if char == '.' {
+			if current != "" {
+				result = append(result, current)
+				current = ""
+			}
+		} else {
+			current += string(char)
+		}
+	}
+
+	if current != "" {
+		result = append(result, current)
+	}
+
+	return result
+}
+
+// backupBeforeSave copies the current config file to a timestamped .bak and prunes old ones
+func (c *ConfigManager) backupBeforeSave() error {
+	data, err := os.ReadFile(c.configPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	backupPath := fmt.Sprintf("%s.%s.bak", c.configPath, time.Now().Format("20060102-150405.000000000"))
+	if err := os.WriteFile(backupPath, data, configFileMode); err != nil {
+		return err
+	}
+
+	backups := c.saveBackups()
+	for len(backups) > maxSaveBackups {
+		os.Remove(backups[0])
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// saveBackups lists the timestamped backups made by Save, oldest first
+func (c *ConfigManager) saveBackups() []string {
+	matches, _ := filepath.Glob(c.configPath + ".*.bak")
+	sort.Strings(matches)
+	return matches
+}
+
+// LatestBackup returns the most recent backup made by Save, or ""
+func (c *ConfigManager) LatestBackup() string {
+	backups := c.saveBackups()
+	if len(backups) == 0 {
+		return ""
+	}
+	return backups[len(backups)-1]
+}
+
+// RestoreLatestBackup replaces the config file with the most recent backup and reloads it
+func (c *ConfigManager) RestoreLatestBackup() (string, error) {
+	latest := c.LatestBackup()
+	if latest == "" {
+		return "", fmt.Errorf("no backups found for %s", c.configPath)
+	}
+
+	data, err := os.ReadFile(latest)
+	if err != nil {
+		return "", err
+	}
+	if err := c.writeFile(c.configPath, data, configFileMode); err != nil {
+		return "", err
+	}
+
+	if c.logger != nil {
+		c.logger.Log("Restored configuration from %s", latest)
+	}
+	return latest, c.Load()
+}
+
+// writeFileAtomic writes data to a temp file in the target directory and renames it
+// into place so readers never observe a partially written config
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
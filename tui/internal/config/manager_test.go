@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,368 +15,6 @@ import (
 	testutils "mcf-dev/tui/internal/testing"
 )
 
-// ConfigManager handles configuration management for the TUI application
-type ConfigManager struct {
-	configPath string
-	config     map[string]interface{}
-	logger     *testutils.TestLogger
-}
-
-// DefaultConfig represents the default configuration structure
-var DefaultConfig = map[string]interface{}{
-	"mcf": map[string]interface{}{
-		"host":           "localhost",
-		"port":           8080,
-		"timeout":        30,
-		"retry_attempts": 3,
-		"retry_delay":    1000,
-		"tls_enabled":    false,
-		"api_version":    "v1",
-	},
-	"tui": map[string]interface{}{
-		"theme":           "dark",
-		"refresh_rate":    1000,
-		"max_log_lines":   1000,
-		"auto_scroll":     true,
-		"show_timestamps": true,
-		"default_view":    "dashboard",
-	},
-	"logging": map[string]interface{}{
-		"level":     "info",
-		"file_path": "mcf-tui.log",
-		"max_size":  10,
-		"max_age":   7,
-		"max_files": 3,
-		"compress":  true,
-	},
-	"performance": map[string]interface{}{
-		"max_goroutines":     50,
-		"cache_size":         100,
-		"gc_percent":         100,
-		"memory_limit_mb":    512,
-		"cpu_limit_percent":  80.0,
-		"disk_limit_percent": 90.0,
-	},
-}
-
-// NewConfigManager creates a new configuration manager instance
-func NewConfigManager(configPath string, logger *testutils.TestLogger) *ConfigManager {
-	return &ConfigManager{
-		configPath: configPath,
-		config:     make(map[string]interface{}),
-		logger:     logger,
-	}
-}
-
-// Load loads configuration from file
-func (c *ConfigManager) Load() error {
-	if c.logger != nil {
-		c.logger.Log("Loading configuration from %s", c.configPath)
-	}
-
-	// Check if config file exists
-	if _, err := os.Stat(c.configPath); os.IsNotExist(err) {
-		if c.logger != nil {
-			c.logger.Log("Config file does not exist, using defaults")
-		}
-		c.config = DefaultConfig
-		return c.Save() // Create default config file
-	}
-
-	data, err := os.ReadFile(c.configPath)
-	if err != nil {
-		if c.logger != nil {
-			c.logger.Error("Failed to read config file: %v", err)
-		}
-		return err
-	}
-
-	err = json.Unmarshal(data, &c.config)
-	if err != nil {
-		if c.logger != nil {
-			c.logger.Error("Failed to unmarshal config: %v", err)
-		}
-		return err
-	}
-
-	if c.logger != nil {
-		c.logger.Log("Configuration loaded successfully")
-	}
-	return nil
-}
-
-// Save saves configuration to file
-func (c *ConfigManager) Save() error {
-	if c.logger != nil {
-		c.logger.Log("Saving configuration to %s", c.configPath)
-	}
-
-	// Create directory if it doesn't exist
-	dir := filepath.Dir(c.configPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		if c.logger != nil {
-			c.logger.Error("Failed to create config directory: %v", err)
-		}
-		return err
-	}
-
-	data, err := json.MarshalIndent(c.config, "", "  ")
-	if err != nil {
-		if c.logger != nil {
-			c.logger.Error("Failed to marshal config: %v", err)
-		}
-		return err
-	}
-
-	err = os.WriteFile(c.configPath, data, 0644)
-	if err != nil {
-		if c.logger != nil {
-			c.logger.Error("Failed to write config file: %v", err)
-		}
-		return err
-	}
-
-	if c.logger != nil {
-		c.logger.Log("Configuration saved successfully")
-	}
-	return nil
-}
-
-// Get retrieves a configuration value using dot notation (e.g., "mcf.host")
-func (c *ConfigManager) Get(key string) (interface{}, bool) {
-	return c.getNestedValue(c.config, key)
-}
-
-// Set sets a configuration value using dot notation
-func (c *ConfigManager) Set(key string, value interface{}) error {
-	if c.logger != nil {
-		c.logger.Log("Setting config key %s to %v", key, value)
-	}
-
-	err := c.setNestedValue(c.config, key, value)
-	if err == nil {
-		return c.Save()
-	}
-	return err
-}
-
-// GetString retrieves a string configuration value
-func (c *ConfigManager) GetString(key string) (string, error) {
-	value, exists := c.Get(key)
-	if !exists {
-		return "", fmt.Errorf("key %s not found", key)
-	}
-
-	str, ok := value.(string)
-	if !ok {
-		return "", fmt.Errorf("key %s is not a string", key)
-	}
-
-	return str, nil
-}
-
-// GetInt retrieves an integer configuration value
-func (c *ConfigManager) GetInt(key string) (int, error) {
-	value, exists := c.Get(key)
-	if !exists {
-		return 0, fmt.Errorf("key %s not found", key)
-	}
-
-	// Handle different numeric types from JSON unmarshaling
-	switch v := value.(type) {
-	case int:
-		return v, nil
-	case float64:
-		return int(v), nil
-	case int64:
-		return int(v), nil
-	default:
-		return 0, fmt.Errorf("key %s is not a number", key)
-	}
-}
-
-// GetBool retrieves a boolean configuration value
-func (c *ConfigManager) GetBool(key string) (bool, error) {
-	value, exists := c.Get(key)
-	if !exists {
-		return false, fmt.Errorf("key %s not found", key)
-	}
-
-	boolean, ok := value.(bool)
-	if !ok {
-		return false, fmt.Errorf("key %s is not a boolean", key)
-	}
-
-	return boolean, nil
-}
-
-// GetFloat retrieves a float configuration value
-func (c *ConfigManager) GetFloat(key string) (float64, error) {
-	value, exists := c.Get(key)
-	if !exists {
-		return 0, fmt.Errorf("key %s not found", key)
-	}
-
-	// Handle different numeric types
-	switch v := value.(type) {
-	case float64:
-		return v, nil
-	case int:
-		return float64(v), nil
-	case int64:
-		return float64(v), nil
-	default:
-		return 0, fmt.Errorf("key %s is not a number", key)
-	}
-}
-
-// Reset resets configuration to defaults
-func (c *ConfigManager) Reset() error {
-	if c.logger != nil {
-		c.logger.Log("Resetting configuration to defaults")
-	}
-
-	c.config = make(map[string]interface{})
-	for k, v := range DefaultConfig {
-		c.config[k] = v
-	}
-
-	return c.Save()
-}
-
-// Backup creates a backup of the current configuration
-func (c *ConfigManager) Backup(backupPath string) error {
-	if c.logger != nil {
-		c.logger.Log("Creating backup at %s", backupPath)
-	}
-
-	// Create backup directory if needed
-	dir := filepath.Dir(backupPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
-	}
-
-	data, err := json.MarshalIndent(c.config, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	return os.WriteFile(backupPath, data, 0644)
-}
-
-// Validate validates the current configuration
-func (c *ConfigManager) Validate() []error {
-	var errors []error
-
-	// Required string fields
-	requiredStrings := map[string]string{
-		"mcf.host":        "MCF host",
-		"mcf.api_version": "API version",
-		"tui.theme":       "TUI theme",
-		"logging.level":   "Log level",
-	}
-
-	for key, description := range requiredStrings {
-		if _, err := c.GetString(key); err != nil {
-			errors = append(errors, fmt.Errorf("%s (%s) is required", description, key))
-		}
-	}
-
-	// Validate numeric ranges
-	port, err := c.GetInt("mcf.port")
-	if err == nil {
-		if port < 1 || port > 65535 {
-			errors = append(errors, fmt.Errorf("mcf.port must be between 1 and 65535"))
-		}
-	}
-
-	timeout, err := c.GetInt("mcf.timeout")
-	if err == nil {
-		if timeout < 1 || timeout > 300 {
-			errors = append(errors, fmt.Errorf("mcf.timeout must be between 1 and 300 seconds"))
-		}
-	}
-
-	return errors
-}
-
-// Helper methods for nested value operations
-func (c *ConfigManager) getNestedValue(config map[string]interface{}, key string) (interface{}, bool) {
-	keys := c.splitKey(key)
-	current := config
-
-	for i, k := range keys {
-		if i == len(keys)-1 {
-			value, exists := current[k]
-			return value, exists
-		}
-
-		next, exists := current[k]
-		if !exists {
-			return nil, false
-		}
-
-		nextMap, ok := next.(map[string]interface{})
-		if !ok {
-			return nil, false
-		}
-
-		current = nextMap
-	}
-
-	return nil, false
-}
-
-func (c *ConfigManager) setNestedValue(config map[string]interface{}, key string, value interface{}) error {
-	keys := c.splitKey(key)
-	current := config
-
-	for i, k := range keys {
-		if i == len(keys)-1 {
-			current[k] = value
-			return nil
-		}
-
-		next, exists := current[k]
-		if !exists {
-			next = make(map[string]interface{})
-			current[k] = next
-		}
-
-		nextMap, ok := next.(map[string]interface{})
-		if !ok {
-			return fmt.Errorf("cannot set nested value: %s is not a map", k)
-		}
-
-		current = nextMap
-	}
-
-	return nil
-}
-
-func (c *ConfigManager) splitKey(key string) []string {
-	// Simple dot notation split
-	result := []string{}
-	current := ""
-
-	for _, char := range key {
-		if char == '.' {
-			if current != "" {
-				result = append(result, current)
-				current = ""
-			}
-		} else {
-			current += string(char)
-		}
-	}
-
-	if current != "" {
-		result = append(result, current)
-	}
-
-	return result
-}
-
 // Test Suite
 type ConfigTestSuite struct {
 	suite.Suite
@@ -635,6 +274,277 @@ func (suite *ConfigTestSuite) TestBackup() {
 	})
 }
 
+func (suite *ConfigTestSuite) TestFileModes() {
+	suite.Run("should write config owner-only", func() {
+		suite.NoError(suite.manager.Save())
+
+		info, err := os.Stat(suite.configPath)
+		suite.Require().NoError(err)
+		suite.Equal(os.FileMode(0600), info.Mode().Perm())
+	})
+
+	suite.Run("should repair a world-readable config on save", func() {
+		suite.Require().NoError(os.Chmod(suite.configPath, 0644))
+		suite.NoError(suite.manager.Set("mcf.host", "repaired"))
+
+		info, err := os.Stat(suite.configPath)
+		suite.Require().NoError(err)
+		suite.Equal(os.FileMode(0600), info.Mode().Perm())
+	})
+
+	suite.Run("should write backups owner-only", func() {
+		backupPath := filepath.Join(suite.tempDir, "backup", "config.json")
+		suite.NoError(suite.manager.Backup(backupPath))
+
+		info, err := os.Stat(backupPath)
+		suite.Require().NoError(err)
+		suite.Equal(os.FileMode(0600), info.Mode().Perm())
+	})
+}
+
+func (suite *ConfigTestSuite) TestSaveBackups() {
+	suite.Run("should back up the previous config before saving", func() {
+		suite.NoError(suite.manager.Set("mcf.host", "first"))
+		suite.NoError(suite.manager.Set("mcf.host", "second"))
+
+		backups := suite.manager.saveBackups()
+		suite.Require().NotEmpty(backups)
+
+		data, err := os.ReadFile(backups[len(backups)-1])
+		suite.Require().NoError(err)
+		suite.Contains(string(data), "first", "Backup should hold the config as it was before the save")
+	})
+
+	suite.Run("should keep a bounded number of backups", func() {
+		for i := 0; i < maxSaveBackups+3; i++ {
+			suite.NoError(suite.manager.Set("mcf.port", 9000+i))
+		}
+
+		suite.LessOrEqual(len(suite.manager.saveBackups()), maxSaveBackups)
+	})
+
+	suite.Run("should restore the latest backup after corruption", func() {
+		suite.NoError(suite.manager.Set("mcf.host", "good"))
+		suite.NoError(suite.manager.Set("mcf.host", "latest"))
+		suite.Require().NoError(os.WriteFile(suite.configPath, []byte("{broken"), 0600))
+
+		// No test logger here: it fails the test on the expected unmarshal error
+		reloaded := NewConfigManager(suite.configPath, nil)
+		err := reloaded.Load()
+		suite.ErrorIs(err, ErrCorruptConfig)
+
+		restored, err := reloaded.RestoreLatestBackup()
+		suite.NoError(err)
+		suite.NotEmpty(restored)
+
+		host, err := reloaded.GetString("mcf.host")
+		suite.NoError(err)
+		suite.Equal("good", host, "Latest backup is the config before the final save")
+	})
+}
+
+func (suite *ConfigTestSuite) TestApplyRaw() {
+	suite.Require().NoError(suite.manager.Load())
+
+	suite.Run("should round-trip a valid edit", func() {
+		raw, err := suite.manager.Raw()
+		suite.Require().NoError(err)
+
+		edited := strings.Replace(raw, `"host": "localhost"`, `"host": "edited-host"`, 1)
+		suite.Require().NotEqual(raw, edited)
+		suite.Require().NoError(suite.manager.ApplyRaw(edited))
+
+		reloaded := NewConfigManager(suite.configPath, suite.logger)
+		suite.Require().NoError(reloaded.Load())
+		host, err := reloaded.GetString("mcf.host")
+		suite.NoError(err)
+		suite.Equal("edited-host", host)
+
+		data, err := os.ReadFile(suite.manager.LatestBackup())
+		suite.Require().NoError(err)
+		suite.Contains(string(data), `"localhost"`, "Previous config should be backed up")
+	})
+
+	suite.Run("should reject invalid edits without writing", func() {
+		before, err := os.ReadFile(suite.configPath)
+		suite.Require().NoError(err)
+
+		for name, text := range map[string]string{
+			"malformed":  `{"mcf": {"host": `,
+			"invalid":    `{"mcf": {"host": "h", "api_version": "v1", "port": 70000}, "tui": {"theme": "dark"}, "logging": {"level": "info"}}`,
+			"wrong type": `{"mcf": {"host": 42}}`,
+		} {
+			err := suite.manager.ApplyRaw(text)
+			suite.ErrorIs(err, ErrInvalidConfig, name)
+		}
+
+		after, err := os.ReadFile(suite.configPath)
+		suite.Require().NoError(err)
+		suite.Equal(string(before), string(after))
+		host, err := suite.manager.GetString("mcf.host")
+		suite.NoError(err)
+		suite.Equal("edited-host", host, "In-memory config should be unchanged")
+	})
+
+	suite.Run("should fill keys the edit leaves out from defaults", func() {
+		suite.Require().NoError(suite.manager.ApplyRaw(`{"mcf": {"port": 9090}}`))
+
+		port, err := suite.manager.GetInt("mcf.port")
+		suite.NoError(err)
+		suite.Equal(9090, port)
+		host, err := suite.manager.GetString("mcf.host")
+		suite.NoError(err)
+		suite.Equal("localhost", host)
+		suite.Contains(suite.manager.DefaultedKeys(), "mcf.host")
+	})
+}
+
+func (suite *ConfigTestSuite) TestReconcile() {
+	suite.Run("should keep unmanaged keys through a load/edit/save cycle", func() {
+		suite.Require().NoError(os.WriteFile(suite.configPath, []byte(`{
+			"mcf": {"host": "custom-host", "legacy_flag": true},
+			"plugins": {"enabled": ["a", "b"]}
+		}`), 0600))
+
+		suite.Require().NoError(suite.manager.Load())
+		suite.Equal([]string{"mcf.legacy_flag", "plugins"}, suite.manager.UnmanagedKeys())
+
+		suite.Require().NoError(suite.manager.Set("mcf.port", 9090))
+
+		reloaded := NewConfigManager(suite.configPath, suite.logger)
+		suite.Require().NoError(reloaded.Load())
+		legacy, err := reloaded.GetBool("mcf.legacy_flag")
+		suite.NoError(err)
+		suite.True(legacy)
+		plugins, ok := reloaded.Get("plugins.enabled")
+		suite.True(ok)
+		suite.Equal([]interface{}{"a", "b"}, plugins)
+		port, err := reloaded.GetInt("mcf.port")
+		suite.NoError(err)
+		suite.Equal(9090, port)
+		suite.Equal([]string{"mcf.legacy_flag", "plugins"}, reloaded.UnmanagedKeys())
+	})
+
+	suite.Run("should fill missing schema keys from defaults", func() {
+		suite.Require().NoError(os.WriteFile(suite.configPath, []byte(`{"mcf": {"host": "custom-host"}}`), 0600))
+
+		suite.Require().NoError(suite.manager.Load())
+
+		host, err := suite.manager.GetString("mcf.host")
+		suite.NoError(err)
+		suite.Equal("custom-host", host, "File values should win over defaults")
+		port, err := suite.manager.GetInt("mcf.port")
+		suite.NoError(err)
+		suite.Equal(8080, port)
+		suite.Contains(suite.manager.DefaultedKeys(), "mcf.port")
+		suite.Contains(suite.manager.DefaultedKeys(), "logging")
+		suite.NotContains(suite.manager.DefaultedKeys(), "mcf.host")
+		suite.Empty(suite.manager.UnmanagedKeys())
+	})
+
+	suite.Run("should never modify DefaultConfig", func() {
+		suite.Require().NoError(os.WriteFile(suite.configPath, []byte(`{}`), 0600))
+		suite.Require().NoError(suite.manager.Load())
+
+		suite.Require().NoError(suite.manager.Set("tui.theme", "light"))
+
+		suite.Equal("dark", DefaultConfig["tui"].(map[string]interface{})["theme"])
+	})
+}
+
+func (suite *ConfigTestSuite) TestFileFormats() {
+	for _, name := range []string{"config.json", "config.yaml", "config.yml"} {
+		suite.Run(name, func() {
+			path := filepath.Join(suite.tempDir, name)
+			manager := NewConfigManager(path, suite.logger)
+			suite.Require().NoError(manager.Load(), "Should create the file from defaults")
+			suite.Require().NoError(manager.SetMany(map[string]interface{}{
+				"mcf.host":             "example.com",
+				"mcf.port":             9090,
+				"tui.auto_scroll":      false,
+				"plugins.lint.enabled": true,
+			}))
+
+			reloaded := NewConfigManager(path, suite.logger)
+			suite.Require().NoError(reloaded.Load())
+
+			host, err := reloaded.GetString("mcf.host")
+			suite.NoError(err)
+			suite.Equal("example.com", host)
+			port, err := reloaded.GetInt("mcf.port")
+			suite.NoError(err)
+			suite.Equal(9090, port)
+			autoScroll, err := reloaded.GetBool("tui.auto_scroll")
+			suite.NoError(err)
+			suite.False(autoScroll)
+			enabled, err := reloaded.GetBool("plugins.lint.enabled")
+			suite.NoError(err)
+			suite.True(enabled, "Nested keys outside the schema should round-trip")
+			suite.Empty(reloaded.Validate())
+		})
+	}
+
+	suite.Run("should read YAML written by other tools", func() {
+		path := filepath.Join(suite.tempDir, "installer.yaml")
+		suite.Require().NoError(os.WriteFile(path, []byte("mcf:\n  host: installer-host\n  port: 7000\ntui:\n  theme: light\n"), 0600))
+
+		manager := NewConfigManager(path, suite.logger)
+		suite.Require().NoError(manager.Load())
+
+		theme, err := manager.GetString("tui.theme")
+		suite.NoError(err)
+		suite.Equal("light", theme)
+		port, err := manager.GetInt("mcf.port")
+		suite.NoError(err)
+		suite.Equal(7000, port)
+		raw, err := manager.Raw()
+		suite.NoError(err)
+		suite.Contains(raw, "host: installer-host", "Raw should use the file's format")
+
+		data, err := os.ReadFile(path)
+		suite.Require().NoError(err)
+		suite.NotContains(string(data), "{", "Should not rewrite YAML as JSON")
+	})
+}
+
+func (suite *ConfigTestSuite) TestSetMany() {
+	suite.Run("should write once for a batch of changes", func() {
+		writes := 0
+		suite.manager.writeFile = func(path string, data []byte, perm os.FileMode) error {
+			writes++
+			return writeFileAtomic(path, data, perm)
+		}
+
+		err := suite.manager.SetMany(map[string]interface{}{
+			"mcf.host":  "batch-host",
+			"mcf.port":  9090,
+			"tui.theme": "light",
+		})
+		suite.NoError(err)
+		suite.Equal(1, writes, "Batch should trigger a single write")
+
+		host, err := suite.manager.GetString("mcf.host")
+		suite.NoError(err)
+		suite.Equal("batch-host", host)
+
+		reloaded := NewConfigManager(suite.configPath, suite.logger)
+		suite.NoError(reloaded.Load())
+		theme, err := reloaded.GetString("tui.theme")
+		suite.NoError(err)
+		suite.Equal("light", theme)
+	})
+
+	suite.Run("should leave no temp files behind after save", func() {
+		suite.NoError(suite.manager.SetMany(map[string]interface{}{"mcf.host": "clean"}))
+
+		entries, err := os.ReadDir(suite.tempDir)
+		suite.NoError(err)
+		for _, entry := range entries {
+			suite.NotContains(entry.Name(), ".tmp-", "Atomic save should clean up temp files")
+		}
+	})
+}
+
 // Integration test
 func TestConfigManager_Integration(t *testing.T) {
 	t.Run("should handle complete configuration lifecycle", func(t *testing.T) {
@@ -800,3 +710,75 @@ func BenchmarkConfigManager_Get(b *testing.B) {
 		manager.Get(key)
 	}
 }
+
+func (suite *ConfigTestSuite) TestApplyEnvOverrides() {
+	suite.Run("should coerce values to the existing types", func() {
+		suite.Require().NoError(suite.manager.Load())
+		suite.T().Setenv("MCF_MCF_HOST", "env-host")
+		suite.T().Setenv("MCF_MCF_PORT", "9443")
+		suite.T().Setenv("MCF_MCF_RETRY_ATTEMPTS", "5")
+		suite.T().Setenv("MCF_TUI_AUTO_SCROLL", "false")
+		suite.T().Setenv("MCF_PERFORMANCE_CPU_LIMIT_PERCENT", "65.5")
+
+		suite.Require().NoError(suite.manager.ApplyEnvOverrides("MCF"))
+
+		host, err := suite.manager.GetString("mcf.host")
+		suite.NoError(err)
+		suite.Equal("env-host", host)
+		port, err := suite.manager.GetInt("mcf.port")
+		suite.NoError(err)
+		suite.Equal(9443, port)
+		retries, err := suite.manager.GetInt("mcf.retry_attempts")
+		suite.NoError(err)
+		suite.Equal(5, retries, "Underscores within a key should map back to the key")
+		autoScroll, err := suite.manager.GetBool("tui.auto_scroll")
+		suite.NoError(err)
+		suite.False(autoScroll)
+		cpu, _ := suite.manager.Get("performance.cpu_limit_percent")
+		suite.Equal(65.5, cpu)
+		suite.Equal([]string{"mcf.host", "mcf.port", "mcf.retry_attempts", "performance.cpu_limit_percent", "tui.auto_scroll"}, suite.manager.Overrides())
+	})
+
+	suite.Run("should report values that do not coerce", func() {
+		manager := NewConfigManager(filepath.Join(suite.T().TempDir(), "config.json"), nil)
+		suite.Require().NoError(manager.Load())
+		suite.T().Setenv("MCF_MCF_PORT", "eighty")
+		suite.T().Setenv("MCF_TUI_AUTO_SCROLL", "sometimes")
+		suite.T().Setenv("MCF_TUI_THEME", "light")
+
+		err := manager.ApplyEnvOverrides("MCF_")
+
+		suite.Require().Error(err)
+		suite.Contains(err.Error(), `MCF_MCF_PORT: "eighty" is not an integer`)
+		suite.Contains(err.Error(), `MCF_TUI_AUTO_SCROLL: "sometimes" is not a boolean`)
+		port, _ := manager.GetInt("mcf.port")
+		suite.Equal(8080, port, "A bad value should leave the file value in place")
+		theme, _ := manager.GetString("tui.theme")
+		suite.Equal("light", theme, "Valid overrides should still apply")
+	})
+
+	suite.Run("should beat the file without being saved to it", func() {
+		path := filepath.Join(suite.T().TempDir(), "config.yaml")
+		suite.Require().NoError(os.WriteFile(path, []byte("mcf:\n  host: file-host\n  port: 7000\ncustom:\n  limit: 3\n"), 0600))
+		manager := NewConfigManager(path, suite.logger)
+		suite.Require().NoError(manager.Load())
+		suite.T().Setenv("MCF_MCF_HOST", "env-host")
+		suite.T().Setenv("MCF_CUSTOM_LIMIT", "12")
+
+		suite.Require().NoError(manager.ApplyEnvOverrides("MCF"))
+		suite.Require().NoError(manager.Set("tui.theme", "light"))
+		suite.Require().NoError(manager.Load())
+
+		host, _ := manager.GetString("mcf.host")
+		suite.Equal("env-host", host, "Overrides should survive a reload")
+		port, _ := manager.GetInt("mcf.port")
+		suite.Equal(7000, port, "The file should still beat the defaults")
+		limit, _ := manager.GetInt("custom.limit")
+		suite.Equal(12, limit, "Keys outside the schema should use the file's type")
+
+		data, err := os.ReadFile(path)
+		suite.Require().NoError(err)
+		suite.Contains(string(data), "host: file-host")
+		suite.NotContains(string(data), "env-host")
+	})
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+)
+
+// watchDebounce is how long the file must stay unchanged before a change is reported
+const watchDebounce = 200 * time.Millisecond
+
+// watchPollInterval is how often the watcher checks the config file
+const watchPollInterval = 50 * time.Millisecond
+
+// Watch reports changes to the config file on the returned channel until ctx
+// is done, when the channel is closed. Bursts of writes are debounced into a
+// single notification, and the receiver is expected to call Load.
+//
+// The file is polled rather than watched by inode, so editors that save by
+// writing a temporary file and renaming it over the original (and Save
+// itself) are followed, as are deletion and re-creation. A missing file is
+// not an error; its creation is reported as a change.
+func (c *ConfigManager) Watch(ctx context.Context) (<-chan struct{}, error) {
+	last, err := statConfig(c.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer close(changes)
+
+		ticker := time.NewTicker(watchPollInterval)
+		defer ticker.Stop()
+
+		var changedAt time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				current, err := statConfig(c.configPath)
+				if err != nil {
+					if c.logger != nil {
+						c.logger.Log("Failed to stat config file: %v", err)
+					}
+					continue
+				}
+				if configChanged(last, current) {
+					last = current
+					changedAt = now
+					continue
+				}
+				if changedAt.IsZero() || now.Sub(changedAt) < watchDebounce {
+					continue
+				}
+				changedAt = time.Time{}
+
+				// A pending notification already covers this change
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// statConfig returns the config file's info, or nil when it does not exist
+func statConfig(path string) (os.FileInfo, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return info, err
+}
+
+// configChanged reports whether the file was created, removed, replaced or rewritten
+func configChanged(previous, current os.FileInfo) bool {
+	if previous == nil || current == nil {
+		return previous != current
+	}
+	return !os.SameFile(previous, current) ||
+		!previous.ModTime().Equal(current.ModTime()) ||
+		previous.Size() != current.Size()
+}
+
+// Watch reports changes to any layer's file on one channel, as ConfigManager.Watch
+// does for a single file. The channel is closed once ctx is done.
+func (l *LayeredConfig) Watch(ctx context.Context) (<-chan struct{}, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	var sources []<-chan struct{}
+	for _, layer := range layerOrder {
+		layerChanges, err := l.layers[layer].Watch(ctx)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		sources = append(sources, layerChanges)
+	}
+
+	changes := make(chan struct{}, 1)
+	var wg sync.WaitGroup
+	for _, source := range sources {
+		wg.Add(1)
+		go func(source <-chan struct{}) {
+			defer wg.Done()
+			for range source {
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			}
+		}(source)
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+		close(changes)
+	}()
+
+	return changes, nil
+}
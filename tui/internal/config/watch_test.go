@@ -0,0 +1,135 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// awaitChange waits for a change notification, failing after a second
+func awaitChange(t *testing.T, changes <-chan struct{}) {
+	t.Helper()
+	select {
+	case _, ok := <-changes:
+		require.True(t, ok, "Watch channel closed early")
+	case <-time.After(time.Second):
+		t.Fatal("No change reported")
+	}
+}
+
+// assertNoChange fails if a notification arrives within the debounce window
+func assertNoChange(t *testing.T, changes <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-changes:
+		t.Fatal("Unexpected change reported")
+	case <-time.After(2 * watchDebounce):
+	}
+}
+
+func TestConfigManager_Watch(t *testing.T) {
+	newWatched := func(t *testing.T) (*ConfigManager, <-chan struct{}) {
+		manager := NewConfigManager(filepath.Join(t.TempDir(), "config.json"), nil)
+		require.NoError(t, manager.Load())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		changes, err := manager.Watch(ctx)
+		require.NoError(t, err)
+		return manager, changes
+	}
+
+	t.Run("should report a rewrite once its writes settle", func(t *testing.T) {
+		manager, changes := newWatched(t)
+		assertNoChange(t, changes)
+
+		for _, host := range []string{"a", "b", "c"} {
+			require.NoError(t, manager.Set("mcf.host", host))
+			time.Sleep(watchPollInterval)
+		}
+
+		awaitChange(t, changes)
+		assertNoChange(t, changes)
+
+		reloaded := NewConfigManager(manager.configPath, nil)
+		require.NoError(t, reloaded.Load())
+		host, _ := reloaded.GetString("mcf.host")
+		assert.Equal(t, "c", host)
+	})
+
+	t.Run("should follow a file replaced by rename", func(t *testing.T) {
+		manager, changes := newWatched(t)
+
+		for _, theme := range []string{"light", "dark"} {
+			tmp := manager.configPath + ".swp"
+			require.NoError(t, os.WriteFile(tmp, []byte(`{"tui": {"theme": "`+theme+`"}}`), 0600))
+			require.NoError(t, os.Rename(tmp, manager.configPath))
+			awaitChange(t, changes)
+		}
+	})
+
+	t.Run("should report deletion and re-creation", func(t *testing.T) {
+		manager, changes := newWatched(t)
+
+		require.NoError(t, os.Remove(manager.configPath))
+		awaitChange(t, changes)
+
+		require.NoError(t, os.WriteFile(manager.configPath, []byte(`{}`), 0600))
+		awaitChange(t, changes)
+	})
+
+	t.Run("should close the channel when the context ends", func(t *testing.T) {
+		manager := NewConfigManager(filepath.Join(t.TempDir(), "config.json"), nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		changes, err := manager.Watch(ctx)
+		require.NoError(t, err)
+
+		cancel()
+
+		select {
+		case _, ok := <-changes:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("Watch channel not closed")
+		}
+	})
+}
+
+func TestLayeredConfig_Watch(t *testing.T) {
+	paths := DefaultLayerPaths(t.TempDir(), t.TempDir())
+	layered := NewLayeredConfig(paths, nil)
+	require.NoError(t, layered.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, err := layered.Watch(ctx)
+	require.NoError(t, err)
+
+	t.Run("should report a change to any layer", func(t *testing.T) {
+		writeLayer(t, paths[LayerProject], `{"tui": {"theme": "light"}}`)
+		awaitChange(t, changes)
+
+		require.NoError(t, layered.Set(LayerGlobal, "tui.refresh_rate", 500))
+		awaitChange(t, changes)
+	})
+
+	t.Run("should close the channel when the context ends", func(t *testing.T) {
+		cancel()
+
+		deadline := time.After(time.Second)
+		for {
+			select {
+			case _, ok := <-changes:
+				if !ok {
+					return
+				}
+			case <-deadline:
+				t.Fatal("Watch channel not closed")
+			}
+		}
+	})
+}
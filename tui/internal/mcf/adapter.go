@@ -1,13 +1,18 @@
 package mcf
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"mcf-dev/tui/internal/ui"
@@ -21,6 +26,15 @@ type MCFAdapter struct {
 	commands      map[string]*Command
 	serenaAdapter *SerenaAdapter
 	logger        *Logger
+
+	claudeBin      string // Claude CLI executable, "claude" unless overridden in tests
+	maxOutputBytes int    // cap on in-memory command output
+	outputDir      string // where full output is saved when it exceeds maxOutputBytes
+	connMu         sync.Mutex
+	lastConnection *ClaudeConnection
+	settingsIssues []SettingsIssue // required keys missing from settings.json at startup
+	index          *operationIndex // cached parse results for agent and command files
+	history        *History        // past runs, persisted under .claude/logs
 }
 
 // MCFSettings represents the MCF configuration
@@ -48,28 +62,44 @@ type Command struct {
 	Category    string
 	Description string
 	Path        string
-	Parameters  []string
+	Parameters  []ParameterDef // declared in the frontmatter `parameters:` list
 }
 
 // CommandResult represents the result of executing an MCF command
 type CommandResult struct {
 	Success bool
-	Output  string
+	Output  string // stdout and stderr interleaved as written
+	Stdout  string
+	Stderr  string
 	Error   string
 	Code    int
+
+	Cancelled bool // the run was stopped through its context
+}
+
+// LogDir returns where log files for mcfRoot are written, honoring MCF_TUI_LOG_DIR
+func LogDir(mcfRoot string) string {
+	if customLogDir := os.Getenv("MCF_TUI_LOG_DIR"); customLogDir != "" {
+		return customLogDir
+	}
+	return filepath.Join(mcfRoot, "logs")
 }
 
 // NewMCFAdapter creates a new MCF adapter
 func NewMCFAdapter(mcfRoot string) (*MCFAdapter, error) {
 	adapter := &MCFAdapter{
-		mcfRoot:  mcfRoot,
-		commands: make(map[string]*Command),
+		mcfRoot:        mcfRoot,
+		commands:       make(map[string]*Command),
+		claudeBin:      "claude",
+		maxOutputBytes: DefaultMaxOutputBytes,
 	}
 
 	// Initialize logger
-	logDir := filepath.Join(mcfRoot, "logs")
-	if customLogDir := os.Getenv("MCF_TUI_LOG_DIR"); customLogDir != "" {
-		logDir = customLogDir
+	logDir := LogDir(mcfRoot)
+
+	adapter.outputDir = logDir
+	if kb, err := strconv.Atoi(os.Getenv("MCF_TUI_MAX_OUTPUT_KB")); err == nil && kb > 0 {
+		adapter.maxOutputBytes = kb * 1024
 	}
 
 	debugMode := os.Getenv("MCF_TUI_DEBUG") == "true"
@@ -84,6 +114,16 @@ func NewMCFAdapter(mcfRoot string) (*MCFAdapter, error) {
 		adapter.logger.Info("MCF Adapter initializing", "mcfRoot", mcfRoot)
 	}
 
+	// Fail early with a clear message when .claude is missing. A missing agents/
+	// or commands/ directory only leaves that list empty.
+	if status := CheckInstallation(mcfRoot); status.RootMissing() {
+		err := fmt.Errorf("MCF not installed in %s: missing .claude", mcfRoot)
+		if adapter.logger != nil {
+			adapter.logger.Error("Installation check failed", err)
+		}
+		return nil, err
+	}
+
 	// Initialize Serena adapter
 	adapter.serenaAdapter = NewSerenaAdapter(mcfRoot)
 
@@ -95,21 +135,17 @@ func NewMCFAdapter(mcfRoot string) (*MCFAdapter, error) {
 		return nil, fmt.Errorf("failed to load MCF settings: %w", err)
 	}
 
-	// Discover agents
-	if err := adapter.discoverAgents(); err != nil {
-		if adapter.logger != nil {
-			adapter.logger.Error("Failed to discover agents", err)
-		}
-		return nil, fmt.Errorf("failed to discover agents: %w", err)
+	// Discover agents and commands, reusing the cached index unless a rebuild is forced
+	if err := adapter.discover(os.Getenv("MCF_TUI_REINDEX") == "true"); err != nil {
+		return nil, err
 	}
 
-	// Discover commands
-	if err := adapter.discoverCommands(); err != nil {
-		if adapter.logger != nil {
-			adapter.logger.Error("Failed to discover commands", err)
-		}
-		return nil, fmt.Errorf("failed to discover commands: %w", err)
+	// A damaged history file only loses past runs; it is rewritten on the next run
+	history, err := LoadHistory(HistoryPath(mcfRoot), historyLimit(mcfRoot))
+	if err != nil && adapter.logger != nil {
+		adapter.logger.Error("Failed to load run history", err)
 	}
+	adapter.history = history
 
 	if adapter.logger != nil {
 		adapter.logger.Info("MCF Adapter initialized successfully",
@@ -131,7 +167,73 @@ func (m *MCFAdapter) loadSettings() error {
 	}
 
 	m.settings = &MCFSettings{}
-	return json.Unmarshal(data, m.settings)
+	if err := json.Unmarshal(data, m.settings); err != nil {
+		return err
+	}
+
+	// Missing required keys are not fatal; report them so they can be repaired
+	raw := map[string]interface{}{}
+	if err := json.Unmarshal(data, &raw); err == nil {
+		m.settingsIssues = CheckSettings(raw)
+	}
+	if len(m.settingsIssues) > 0 && m.logger != nil {
+		m.logger.Info("settings.json is incomplete", "issues", len(m.settingsIssues))
+	}
+	return nil
+}
+
+// SettingsIssues returns the required-key problems found when settings were loaded
+func (m *MCFAdapter) SettingsIssues() []SettingsIssue {
+	return m.settingsIssues
+}
+
+// discover scans agents and commands through the operation index and saves it.
+// With reindex set the cached index is ignored and every file is parsed again.
+func (m *MCFAdapter) discover(reindex bool) error {
+	if reindex {
+		m.index = newOperationIndex()
+	} else {
+		m.index = loadOperationIndex(m.mcfRoot)
+	}
+	m.agents = nil
+	m.commands = make(map[string]*Command)
+
+	if err := m.discoverAgents(); err != nil {
+		if m.logger != nil {
+			m.logger.Error("Failed to discover agents", err)
+		}
+		return fmt.Errorf("failed to discover agents: %w", err)
+	}
+
+	if err := m.discoverCommands(); err != nil {
+		if m.logger != nil {
+			m.logger.Error("Failed to discover commands", err)
+		}
+		return fmt.Errorf("failed to discover commands: %w", err)
+	}
+
+	// A cache that cannot be written only costs the next startup a full scan
+	if err := m.index.save(m.mcfRoot); err != nil && m.logger != nil {
+		m.logger.Error("Failed to save operation index", err)
+	}
+	if m.logger != nil {
+		m.logger.Debug("Operation index loaded", "hits", m.index.hits, "misses", m.index.misses)
+	}
+	return nil
+}
+
+// Reindex discards the cached index and re-scans all agent and command files
+func (m *MCFAdapter) Reindex() error {
+	return m.discover(true)
+}
+
+// indexKey is the index key for an operation file
+func (m *MCFAdapter) indexKey(path string) string {
+	rel, err := filepath.Rel(m.mcfRoot, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
 }
 
 // discoverAgents discovers available MCF agents
@@ -140,14 +242,31 @@ func (m *MCFAdapter) discoverAgents() error {
 
 	return filepath.WalkDir(agentsDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
+			if path == agentsDir && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
 			return err
 		}
 
 		if !d.IsDir() && strings.HasSuffix(path, ".md") {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			key := m.indexKey(path)
+			if entry, ok := m.index.lookup(key, info); ok && entry.Agent != nil {
+				agent := *entry.Agent
+				agent.LastActive = time.Now()
+				m.agents = append(m.agents, &agent)
+				return nil
+			}
+
 			agent, err := m.parseAgentFile(path)
 			if err != nil {
 				return err
 			}
+			m.index.store(key, info, indexEntry{Agent: agent})
 			m.agents = append(m.agents, agent)
 		}
 
@@ -201,14 +320,31 @@ func (m *MCFAdapter) discoverCommands() error {
 
 	return filepath.WalkDir(commandsDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
+			if path == commandsDir && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
 			return err
 		}
 
 		if !d.IsDir() && strings.HasSuffix(path, ".md") {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			key := m.indexKey(path)
+			if entry, ok := m.index.lookup(key, info); ok && entry.Command != nil {
+				command := *entry.Command
+				command.Path = path // the project may have moved since indexing
+				m.commands[command.Name] = &command
+				return nil
+			}
+
 			command, err := m.parseCommandFile(path)
 			if err != nil {
 				return err
 			}
+			m.index.store(key, info, indexEntry{Command: command})
 			m.commands[command.Name] = command
 		}
 
@@ -256,6 +392,15 @@ func (m *MCFAdapter) parseCommandFile(path string) (*Command, error) {
 		}
 	}
 
+	params, err := parseCommandParameters(string(content))
+	if err != nil {
+		// A broken frontmatter block should not hide the command itself
+		if m.logger != nil {
+			m.logger.Error("Ignoring command parameters", err, "file", path)
+		}
+	}
+	command.Parameters = params
+
 	return command, nil
 }
 
@@ -292,6 +437,15 @@ func (m *MCFAdapter) GetCommandsByCategory() map[string][]*Command {
 
 // ExecuteCommand executes an MCF command
 func (m *MCFAdapter) ExecuteCommand(commandName string, args []string) (*CommandResult, error) {
+	return m.ExecuteCommandStreaming(context.Background(), commandName, args, nil)
+}
+
+// ExecuteCommandStreaming executes an MCF command like ExecuteCommand, passing each
+// line of process output to onLine as it is written. Simulated commands produce no
+// lines. The returned result still holds the full captured output.
+// Cancelling ctx kills the command's process and its children; the result is then
+// marked Cancelled.
+func (m *MCFAdapter) ExecuteCommandStreaming(ctx context.Context, commandName string, args []string, onLine OutputHandler) (*CommandResult, error) {
 	startTime := time.Now()
 
 	if m.logger != nil {
@@ -314,12 +468,17 @@ func (m *MCFAdapter) ExecuteCommand(commandName string, args []string) (*Command
 	}
 
 	// Try to execute the actual command if it's a real MCF command
-	result, err := m.executeRealCommand(cmd, args)
+	result, err := m.executeRealCommand(ctx, cmd, args, onLine)
 	if err == nil {
+		if ctx.Err() != nil && !result.Success {
+			result.Cancelled = true
+			result.Error = "cancelled: " + ctx.Err().Error()
+		}
 		duration := time.Since(startTime)
 		if m.logger != nil {
 			m.logger.LogCommandExecution(commandName, args, result.Success, result.Output, duration)
 		}
+		m.recordHistory(commandName, args, result, startTime, duration)
 		return result, nil
 	}
 
@@ -334,12 +493,30 @@ func (m *MCFAdapter) ExecuteCommand(commandName string, args []string) (*Command
 			m.logger.LogCommandExecution(commandName, args, result.Success, result.Output, duration)
 		}
 	}
+	if fallbackErr == nil {
+		m.recordHistory(commandName, args, result, startTime, duration)
+	}
 
 	return result, fallbackErr
 }
 
+// History returns the record of past command runs
+func (m *MCFAdapter) History() *History {
+	return m.history
+}
+
+// recordHistory adds a finished run to the history; a failed write is only logged
+func (m *MCFAdapter) recordHistory(commandName string, args []string, result *CommandResult, started time.Time, duration time.Duration) {
+	if m.history == nil {
+		return
+	}
+	if err := m.history.Add(NewHistoryEntry(commandName, args, result, started, duration)); err != nil && m.logger != nil {
+		m.logger.Error("Failed to save run history", err)
+	}
+}
+
 // executeRealCommand attempts to execute a real Claude command
-func (m *MCFAdapter) executeRealCommand(cmd *Command, args []string) (*CommandResult, error) {
+func (m *MCFAdapter) executeRealCommand(ctx context.Context, cmd *Command, args []string, onLine OutputHandler) (*CommandResult, error) {
 	// Read the command file to understand how to execute it
 	content, err := os.ReadFile(cmd.Path)
 	if err != nil {
@@ -351,25 +528,25 @@ func (m *MCFAdapter) executeRealCommand(cmd *Command, args []string) (*CommandRe
 
 	// Check if this is a Claude command (has YAML frontmatter)
 	if strings.HasPrefix(commandContent, "---") {
-		return m.executeClaudeCommand(cmd, commandContent, args)
+		return m.executeClaudeCommand(ctx, cmd, commandContent, args, onLine)
 	}
 
 	// Look for shell execution patterns
 	if strings.Contains(commandContent, "bash") || strings.Contains(commandContent, "shell") {
-		return m.executeShellCommand(commandContent, args)
+		return m.executeShellCommand(ctx, commandContent, args, onLine)
 	}
 
 	return nil, fmt.Errorf("unable to execute command")
 }
 
 // executeClaudeCommand executes a Claude workflow command via Claude Code CLI
-func (m *MCFAdapter) executeClaudeCommand(cmd *Command, content string, args []string) (*CommandResult, error) {
+func (m *MCFAdapter) executeClaudeCommand(ctx context.Context, cmd *Command, content string, args []string, onLine OutputHandler) (*CommandResult, error) {
 	if m.logger != nil {
 		m.logger.Info("Executing Claude command via CLI", "command", cmd.Name, "file", cmd.Path)
 	}
 
 	// Method 1: Try to execute via Claude Code CLI directly
-	result, err := m.executeViaClaude(cmd, args)
+	result, err := m.executeViaClaude(ctx, cmd, args, onLine)
 	if err == nil {
 		return result, nil
 	}
@@ -380,7 +557,7 @@ func (m *MCFAdapter) executeClaudeCommand(cmd *Command, content string, args []s
 
 	// Method 2: Try to execute via shell if it has shell commands
 	if strings.Contains(content, "bash") || strings.Contains(content, "shell") {
-		return m.executeShellCommand(content, args)
+		return m.executeShellCommand(ctx, content, args, onLine)
 	}
 
 	// Method 3: Try to simulate the command execution
@@ -388,30 +565,25 @@ func (m *MCFAdapter) executeClaudeCommand(cmd *Command, content string, args []s
 }
 
 // executeViaClaude executes command through Claude Code CLI
-func (m *MCFAdapter) executeViaClaude(cmd *Command, args []string) (*CommandResult, error) {
-	// Execute as Claude slash command with your custom environment
-	claudeCommand := fmt.Sprintf("/%s", cmd.Name)
-	if len(args) > 0 {
-		claudeCommand += " " + strings.Join(args, " ")
+func (m *MCFAdapter) executeViaClaude(ctx context.Context, cmd *Command, args []string, onLine OutputHandler) (*CommandResult, error) {
+	// Report a missing CLI clearly instead of surfacing exec's lookup error
+	if err := m.checkClaudeBin(); err != nil {
+		if m.logger != nil {
+			m.logger.Error("Claude CLI unavailable", err, "command", cmd.Name)
+		}
+		return &CommandResult{Success: false, Error: err.Error(), Code: 127}, nil
 	}
 
+	// Execute as Claude slash command with your custom environment
+	invocation := m.claudeInvocation(cmd, args)
+	claudeCommand := invocation.Args[len(invocation.Args)-1]
+
 	if m.logger != nil {
 		m.logger.Info("Executing Claude command with local proxy", "command", claudeCommand)
 	}
 
 	// Create command with your specific environment and flags (matching claude.sh)
-	claudeCmd := exec.Command("claude", "--dangerously-skip-permissions", "-p", claudeCommand)
-	claudeCmd.Dir = m.mcfRoot
-
-	// Set your custom environment variables (matching your claude.sh)
-	homeDir, _ := os.UserHomeDir()
-	claudeCmd.Env = append(os.Environ(),
-		"ANTHROPIC_BASE_URL=http://localhost:4141",
-		"ANTHROPIC_AUTH_TOKEN=dummy",
-		fmt.Sprintf("CLAUDE_CONFIG_DIR=%s/mcf-dev/.claude", homeDir),
-		"ANTHROPIC_MODEL=claude-3.5-sonnet",
-		"ANTHROPIC_SMALL_FAST_MODEL=grok-code-fast-1",
-	)
+	claudeCmd := invocation.Cmd(ctx)
 
 	if m.logger != nil {
 		m.logger.Info("Executing with environment",
@@ -420,35 +592,109 @@ func (m *MCFAdapter) executeViaClaude(cmd *Command, args []string) (*CommandResu
 			"configDir", fmt.Sprintf("%s/.claude", m.mcfRoot))
 	}
 
-	output, err := claudeCmd.CombinedOutput()
+	output, err := m.runCapped(claudeCmd, onLine)
+	result := output.result(err)
 
 	if err != nil {
 		if m.logger != nil {
 			m.logger.Error("Claude command failed", err,
 				"command", claudeCommand,
-				"output", string(output))
+				"output", output.Combined)
 		}
 
 		// Return the error but with output if available
-		return &CommandResult{
-			Success: false,
-			Output:  string(output),
-			Error:   err.Error(),
-			Code:    1,
-		}, nil
+		return result, nil
 	}
 
 	if m.logger != nil {
 		m.logger.Info("Claude command executed successfully via local proxy",
 			"command", claudeCommand,
-			"outputLength", len(output))
+			"outputLength", len(output.Combined))
 	}
 
-	return &CommandResult{
-		Success: true,
-		Output:  string(output),
-		Code:    0,
-	}, nil
+	return result, nil
+}
+
+// capturedOutput holds a command's output both interleaved and split by stream
+type capturedOutput struct {
+	Combined string
+	Stdout   string
+	Stderr   string
+}
+
+// result builds a CommandResult, deriving success and code from the exit status only
+func (o capturedOutput) result(err error) *CommandResult {
+	result := &CommandResult{
+		Success: err == nil,
+		Output:  o.Combined,
+		Stdout:  o.Stdout,
+		Stderr:  o.Stderr,
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+		result.Code = 1
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			result.Code = exitErr.ExitCode()
+		}
+	}
+
+	return result
+}
+
+// runCapped runs cmd capturing stdout and stderr separately and interleaved, each capped
+// at maxOutputBytes. Oversized combined output is truncated with a marker and saved in full to outputDir.
+// When onLine is set, every line is also passed to it as it is written, uncapped.
+func (m *MCFAdapter) runCapped(cmd *exec.Cmd, onLine OutputHandler) (capturedOutput, error) {
+	combined := newCappedOutput(m.maxOutputBytes, m.outputDir)
+	stdout := newCappedOutput(m.maxOutputBytes, "")
+	stderr := newCappedOutput(m.maxOutputBytes, "")
+	stdoutWriters := []io.Writer{stdout, combined}
+	stderrWriters := []io.Writer{stderr, combined}
+
+	var stdoutLines, stderrLines *lineWriter
+	if onLine != nil {
+		stdoutLines = &lineWriter{stream: "stdout", handle: onLine}
+		stderrLines = &lineWriter{stream: "stderr", handle: onLine}
+		stdoutWriters = append(stdoutWriters, stdoutLines)
+		stderrWriters = append(stderrWriters, stderrLines)
+	}
+	cmd.Stdout = io.MultiWriter(stdoutWriters...)
+	cmd.Stderr = io.MultiWriter(stderrWriters...)
+
+	err := cmd.Run()
+	combined.Close()
+	if onLine != nil {
+		stdoutLines.Flush()
+		stderrLines.Flush()
+	}
+
+	if combined.Truncated() && m.logger != nil {
+		m.logger.Info("Command output truncated", "bytes", combined.total, "savedTo", combined.SpillPath())
+	}
+
+	return capturedOutput{
+		Combined: combined.String(),
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+	}, err
+}
+
+// claudeEnv returns the environment used for every Claude CLI invocation (matching claude.sh)
+func claudeEnv() []string {
+	return append(os.Environ(), claudeEnvOverrides()...)
+}
+
+// claudeEnvOverrides lists the variables claudeEnv adds to the inherited environment
+func claudeEnvOverrides() []string {
+	homeDir, _ := os.UserHomeDir()
+	return []string{
+		"ANTHROPIC_BASE_URL=http://localhost:4141",
+		"ANTHROPIC_AUTH_TOKEN=dummy",
+		fmt.Sprintf("CLAUDE_CONFIG_DIR=%s/mcf-dev/.claude", homeDir),
+		"ANTHROPIC_MODEL=claude-3.5-sonnet",
+		"ANTHROPIC_SMALL_FAST_MODEL=grok-code-fast-1",
+	}
 }
 
 // simulateClaudeCommand provides fallback simulation when CLI execution fails
@@ -511,45 +757,15 @@ func (m *MCFAdapter) simulateClaudeCommand(cmd *Command, content string, args []
 }
 
 // executeShellCommand executes a shell-based MCF command
-func (m *MCFAdapter) executeShellCommand(commandContent string, args []string) (*CommandResult, error) {
-	// Extract shell command from the content (simplified)
-	lines := strings.Split(commandContent, "\n")
-	var shellCmd string
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "command:") || strings.HasPrefix(line, "exec:") {
-			shellCmd = strings.TrimSpace(strings.SplitN(line, ":", 2)[1])
-			break
-		}
-		if strings.Contains(line, "bash") || strings.Contains(line, "sh") {
-			shellCmd = line
-			break
-		}
-	}
-
-	if shellCmd == "" {
-		return nil, fmt.Errorf("no shell command found")
-	}
-
-	// Execute the command
-	output, err := exec.Command("bash", "-c", shellCmd).CombinedOutput()
-	result := &CommandResult{
-		Output: string(output),
-		Code:   0,
-	}
-
+func (m *MCFAdapter) executeShellCommand(ctx context.Context, commandContent string, args []string, onLine OutputHandler) (*CommandResult, error) {
+	invocation, err := shellInvocation(commandContent)
 	if err != nil {
-		result.Success = false
-		result.Error = err.Error()
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			result.Code = exitErr.ExitCode()
-		}
-	} else {
-		result.Success = true
+		return nil, err
 	}
 
-	return result, nil
+	// Execute the command
+	output, err := m.runCapped(invocation.Cmd(ctx), onLine)
+	return output.result(err), nil
 }
 
 // executeSimulatedCommand provides fallback responses for known commands
@@ -593,6 +809,17 @@ func (m *MCFAdapter) GetSerenaStatus() string {
 	return "disabled"
 }
 
+// CheckHealth verifies the MCF installation and settings.json are still readable
+func (m *MCFAdapter) CheckHealth() error {
+	if status := CheckInstallation(m.mcfRoot); !status.Installed() {
+		return fmt.Errorf("MCF installation missing %s", strings.Join(status.MissingDirs, ", "))
+	}
+	if _, err := LoadRawSettings(m.mcfRoot); err != nil {
+		return err
+	}
+	return nil
+}
+
 // GetSerenaAdapter returns the Serena adapter instance
 func (m *MCFAdapter) GetSerenaAdapter() *SerenaAdapter {
 	return m.serenaAdapter
@@ -0,0 +1,147 @@
+package mcf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Claude connection states reported by TestClaudeConnection
+const (
+	ClaudeAuthenticated   = "authenticated"
+	ClaudeUnauthenticated = "unauthenticated"
+	ClaudeMissingKey      = "missing-key"
+	ClaudeNotInstalled    = "not-installed"
+	ClaudeUnreachable     = "unreachable"
+)
+
+// ClaudeConnectionTimeout bounds the connection check so the UI never waits on a hung CLI
+const ClaudeConnectionTimeout = 15 * time.Second
+
+// claudeConnectionTTL is how long a connection result is reused before checking again
+const claudeConnectionTTL = time.Minute
+
+// ClaudeConnection is the outcome of a Claude CLI connection check
+type ClaudeConnection struct {
+	Status      string
+	Detail      string
+	Remediation string
+	CheckedAt   time.Time
+}
+
+// OK reports whether Claude is ready to run commands
+func (c ClaudeConnection) OK() bool {
+	return c.Status == ClaudeAuthenticated
+}
+
+// TestClaudeConnection runs a minimal Claude CLI prompt to verify authentication.
+// Results are cached briefly; pass force to bypass the cache.
+func (m *MCFAdapter) TestClaudeConnection(timeout time.Duration, force bool) ClaudeConnection {
+	m.connMu.Lock()
+	defer m.connMu.Unlock()
+
+	if !force && m.lastConnection != nil && time.Since(m.lastConnection.CheckedAt) < claudeConnectionTTL {
+		return *m.lastConnection
+	}
+
+	result := m.checkClaudeConnection(timeout)
+	m.lastConnection = &result
+
+	if m.logger != nil {
+		m.logger.Info("Claude connection checked", "status", result.Status, "detail", result.Detail)
+	}
+
+	return result
+}
+
+// checkClaudeConnection performs the uncached connection check
+func (m *MCFAdapter) checkClaudeConnection(timeout time.Duration) ClaudeConnection {
+	return probeClaudeConnection(m.claudeBin, m.mcfRoot, timeout)
+}
+
+// probeClaudeConnection runs bin in dir with a one-turn prompt and classifies the outcome
+func probeClaudeConnection(bin, dir string, timeout time.Duration) ClaudeConnection {
+	result := ClaudeConnection{CheckedAt: time.Now()}
+
+	if _, err := lookupClaudeBin(bin); err != nil {
+		result.Status = ClaudeNotInstalled
+		result.Detail = "claude CLI not found in PATH"
+		result.Remediation = "Install Claude Code (npm install -g @anthropic-ai/claude-code) and make sure it is on your PATH."
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// A single tool-free turn is enough to exercise authentication
+	cmd := exec.CommandContext(ctx, bin, "-p", "Reply with OK", "--max-turns", "1")
+	cmd.Dir = dir
+	cmd.Env = claudeEnv()
+	output, err := cmd.CombinedOutput()
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		result.Status = ClaudeUnreachable
+		result.Detail = "no response within " + timeout.String()
+		result.Remediation = "Check your network connection or API proxy, then retry."
+		return result
+	}
+
+	text := strings.ToLower(string(output))
+	switch {
+	case err == nil:
+		result.Status = ClaudeAuthenticated
+		result.Detail = "Claude CLI responded"
+	case strings.Contains(text, "api key") && (strings.Contains(text, "missing") || strings.Contains(text, "not set") || strings.Contains(text, "not found")):
+		result.Status = ClaudeMissingKey
+		result.Detail = firstLine(string(output))
+		result.Remediation = "Set ANTHROPIC_API_KEY or run `claude` once to store a key in your keychain."
+	case strings.Contains(text, "401") || strings.Contains(text, "unauthorized") ||
+		strings.Contains(text, "authentication") || strings.Contains(text, "invalid api key") || strings.Contains(text, "login"):
+		result.Status = ClaudeUnauthenticated
+		result.Detail = firstLine(string(output))
+		result.Remediation = "Run `claude /login` or replace the stored API key."
+	default:
+		result.Status = ClaudeUnreachable
+		result.Detail = firstLine(string(output))
+		if result.Detail == "" {
+			result.Detail = err.Error()
+		}
+		result.Remediation = "Run `claude -p \"hello\"` manually to inspect the error."
+	}
+
+	return result
+}
+
+// ErrClaudeNotFound is wrapped by the error returned when the Claude CLI is not installed
+var ErrClaudeNotFound = errors.New("claude CLI not found")
+
+// checkClaudeBin verifies the Claude CLI can be started, so commands fail with
+// install guidance and the searched PATH rather than a raw exec error
+func (m *MCFAdapter) checkClaudeBin() error {
+	_, err := lookupClaudeBin(m.claudeBin)
+	return err
+}
+
+// lookupClaudeBin resolves the Claude CLI executable on PATH
+func lookupClaudeBin(bin string) (string, error) {
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		return "", fmt.Errorf("%w: install Claude Code (npm install -g @anthropic-ai/claude-code) and make sure it is on your PATH (searched %s)",
+			ErrClaudeNotFound, os.Getenv("PATH"))
+	}
+	return path, nil
+}
+
+// firstLine returns the first non-empty line of s
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package mcf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAdapter builds an adapter over a minimal MCF layout in a temp directory
+func newTestAdapter(t *testing.T) *MCFAdapter {
+	t.Helper()
+	return newTestAdapterWith(t, nil)
+}
+
+// newTestAdapterWith is newTestAdapter plus extra files (relative to the root)
+func newTestAdapterWith(t *testing.T, files map[string]string) *MCFAdapter {
+	t.Helper()
+	t.Setenv("MCF_TUI_LOG_DIR", t.TempDir())
+
+	root := t.TempDir()
+	writeFile(t, root, ".claude/settings.json", `{"version": "1.0.0"}`)
+	writeFile(t, root, ".claude/agents/orchestrator.md", "# Orchestrator")
+	writeFile(t, root, ".claude/commands/project/analyze.md", "# Analyze")
+	for rel, content := range files {
+		writeFile(t, root, rel, content)
+	}
+
+	adapter, err := NewMCFAdapter(root)
+	require.NoError(t, err)
+	return adapter
+}
+
+// fakeClaude writes an executable script standing in for the Claude CLI
+func fakeClaude(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "claude")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0755))
+	return path
+}
+
+func TestTestClaudeConnection(t *testing.T) {
+	t.Run("should classify CLI responses", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			script   string
+			expected string
+		}{
+			{"authenticated", "echo OK", ClaudeAuthenticated},
+			{"unauthenticated", "echo 'API Error: 401 Unauthorized' >&2; exit 1", ClaudeUnauthenticated},
+			{"missing key", "echo 'Missing API key. Run /login' >&2; exit 1", ClaudeMissingKey},
+			{"unknown failure", "echo 'boom' >&2; exit 1", ClaudeUnreachable},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				adapter := newTestAdapter(t)
+				adapter.claudeBin = fakeClaude(t, tt.script)
+
+				result := adapter.TestClaudeConnection(5*time.Second, true)
+
+				assert.Equal(t, tt.expected, result.Status)
+				if !result.OK() {
+					assert.NotEmpty(t, result.Remediation)
+				}
+			})
+		}
+	})
+
+	t.Run("should report a missing CLI", func(t *testing.T) {
+		adapter := newTestAdapter(t)
+		adapter.claudeBin = filepath.Join(t.TempDir(), "no-such-claude")
+
+		result := adapter.TestClaudeConnection(time.Second, true)
+
+		assert.Equal(t, ClaudeNotInstalled, result.Status)
+	})
+
+	t.Run("should explain a missing CLI instead of failing commands with an exec error", func(t *testing.T) {
+		t.Setenv("PATH", "/opt/nowhere")
+		adapter := newTestAdapterWith(t, map[string]string{".claude/commands/review.md": "---\ndescription: Reviews\n---\n"})
+		adapter.claudeBin = filepath.Join(t.TempDir(), "no-such-claude")
+
+		result, err := adapter.ExecuteCommand("review", nil)
+
+		require.NoError(t, err)
+		assert.False(t, result.Success)
+		assert.Contains(t, result.Error, "claude CLI not found")
+		assert.Contains(t, result.Error, "npm install -g @anthropic-ai/claude-code")
+		assert.Contains(t, result.Error, "/opt/nowhere")
+		assert.NotContains(t, result.Error, "executable file not found")
+		assert.ErrorIs(t, adapter.checkClaudeBin(), ErrClaudeNotFound)
+	})
+
+	t.Run("should time out a hung CLI", func(t *testing.T) {
+		adapter := newTestAdapter(t)
+		adapter.claudeBin = fakeClaude(t, "exec sleep 5")
+
+		start := time.Now()
+		result := adapter.TestClaudeConnection(200*time.Millisecond, true)
+
+		assert.Equal(t, ClaudeUnreachable, result.Status)
+		assert.Less(t, time.Since(start), 3*time.Second)
+	})
+
+	t.Run("should cache results until forced", func(t *testing.T) {
+		adapter := newTestAdapter(t)
+		counter := filepath.Join(t.TempDir(), "calls")
+		adapter.claudeBin = fakeClaude(t, "echo x >> "+counter+"; echo OK")
+
+		adapter.TestClaudeConnection(5*time.Second, false)
+		adapter.TestClaudeConnection(5*time.Second, false)
+		calls, err := os.ReadFile(counter)
+		require.NoError(t, err)
+		assert.Equal(t, "x\n", string(calls), "Second check should be served from cache")
+
+		adapter.TestClaudeConnection(5*time.Second, true)
+		calls, err = os.ReadFile(counter)
+		require.NoError(t, err)
+		assert.Equal(t, "x\nx\n", string(calls), "Forced check should bypass cache")
+	})
+}
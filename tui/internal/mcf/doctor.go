@@ -0,0 +1,355 @@
+package mcf
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DoctorStatus is the outcome of a single doctor check
+type DoctorStatus int
+
+const (
+	DoctorOK   DoctorStatus = iota
+	DoctorWarn              // usable, but worth fixing
+	DoctorFail              // MCF will not work until fixed
+)
+
+// DoctorCheck is one diagnosed aspect of an MCF install
+type DoctorCheck struct {
+	Name        string
+	Status      DoctorStatus
+	Detail      string
+	Remediation string
+
+	repair func() error // automatic fix applied by `doctor --fix`, nil when manual
+}
+
+// Fixable reports whether a failed or warned check can be repaired automatically
+func (c DoctorCheck) Fixable() bool {
+	return c.Status != DoctorOK && c.repair != nil
+}
+
+// Fix applies the automatic repair. Repairs are idempotent, so fixing an
+// already repaired install changes nothing; re-run Diagnose to verify.
+func (c DoctorCheck) Fix() error {
+	if c.repair == nil {
+		return errors.New("no automatic fix for " + c.Name)
+	}
+	return c.repair()
+}
+
+// String renders the check as a single line without its status icon
+func (c DoctorCheck) String() string {
+	if c.Detail == "" {
+		return c.Name
+	}
+	return fmt.Sprintf("%s: %s", c.Name, c.Detail)
+}
+
+// ConfigPath returns the location of the optional config.yaml for an MCF root
+func ConfigPath(mcfRoot string) string {
+	return filepath.Join(mcfRoot, ".claude", "config.yaml")
+}
+
+// Diagnose checks an existing install: the .claude layout, settings.json and
+// config.yaml, the Claude CLI and its authentication, the permissions of command
+// files and of files holding secrets. The auth check runs a real prompt, so it
+// can take up to ClaudeConnectionTimeout.
+func Diagnose(mcfRoot string) []DoctorCheck {
+	return []DoctorCheck{
+		checkStructure(mcfRoot),
+		checkSettingsFile(mcfRoot),
+		checkConfigFile(mcfRoot),
+		checkClaudeCLI(),
+		checkClaudeAuth(mcfRoot),
+		checkCommandPermissions(mcfRoot),
+		checkSecretFileModes(mcfRoot),
+	}
+}
+
+// DoctorFailed reports whether any check failed outright
+func DoctorFailed(checks []DoctorCheck) bool {
+	for _, check := range checks {
+		if check.Status == DoctorFail {
+			return true
+		}
+	}
+	return false
+}
+
+func checkStructure(mcfRoot string) DoctorCheck {
+	check := DoctorCheck{Name: ".claude structure"}
+	status := CheckInstallation(mcfRoot)
+
+	switch {
+	case !status.Installed():
+		check.Status = DoctorFail
+		check.Detail = "missing " + strings.Join(status.MissingDirs, ", ")
+		check.Remediation = "Run `mcf-tui init --force` to recreate the missing directories."
+		check.repair = func() error { return createRequiredDirs(mcfRoot) }
+	case len(status.EmptyDirs) > 0:
+		check.Status = DoctorWarn
+		check.Detail = "no operations in " + strings.Join(status.EmptyDirs, ", ")
+		check.Remediation = "Add .md files there or re-run the MCF installer."
+	default:
+		check.Detail = strings.Join(RequiredDirs, ", ") + " present"
+	}
+	return check
+}
+
+func checkSettingsFile(mcfRoot string) DoctorCheck {
+	check := DoctorCheck{Name: "settings.json"}
+
+	settings, err := LoadRawSettings(mcfRoot)
+	switch {
+	case os.IsNotExist(err):
+		check.Status = DoctorFail
+		check.Detail = SettingsPath(mcfRoot) + " not found"
+		check.Remediation = "Run `mcf-tui init --force` to write a default settings.json."
+		check.repair = func() error {
+			if err := createRequiredDirs(mcfRoot); err != nil {
+				return err
+			}
+			return writeDefaultSettings(mcfRoot, false)
+		}
+		return check
+	case err != nil:
+		check.Status = DoctorFail
+		check.Detail = err.Error()
+		check.Remediation = "Fix the JSON syntax in " + SettingsPath(mcfRoot) + "."
+		return check
+	}
+
+	if issues := CheckSettings(settings); len(issues) > 0 {
+		problems := make([]string, len(issues))
+		for i, issue := range issues {
+			problems[i] = issue.String()
+		}
+		check.Status = DoctorFail
+		check.Detail = strings.Join(problems, ", ")
+		check.Remediation = "Run `mcf-tui config repair` to fill in defaults."
+		check.repair = func() error {
+			_, _, err := RepairSettings(mcfRoot)
+			return err
+		}
+		return check
+	}
+
+	check.Detail = "valid"
+	return check
+}
+
+// checkConfigFile validates config.yaml when present; it is optional
+func checkConfigFile(mcfRoot string) DoctorCheck {
+	check := DoctorCheck{Name: "config.yaml"}
+
+	data, err := os.ReadFile(ConfigPath(mcfRoot))
+	switch {
+	case os.IsNotExist(err):
+		check.Detail = "not present, defaults apply"
+		return check
+	case err != nil:
+		check.Status = DoctorFail
+		check.Detail = err.Error()
+		return check
+	}
+
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		check.Status = DoctorFail
+		check.Detail = "invalid YAML: " + err.Error()
+		check.Remediation = "Fix the YAML syntax in " + ConfigPath(mcfRoot) + "."
+		return check
+	}
+
+	check.Detail = "valid"
+	return check
+}
+
+func checkClaudeCLI() DoctorCheck {
+	check := DoctorCheck{Name: "Claude CLI"}
+
+	path, err := lookupClaudeBin("claude")
+	if err != nil {
+		check.Status = DoctorFail
+		check.Detail = "claude not found in PATH"
+		check.Remediation = "Install Claude Code (npm install -g @anthropic-ai/claude-code) and make sure it is on your PATH."
+		return check
+	}
+
+	check.Detail = path
+	return check
+}
+
+// checkClaudeAuth runs a one-turn prompt through the Claude CLI to verify authentication
+func checkClaudeAuth(mcfRoot string) DoctorCheck {
+	check := DoctorCheck{Name: "Claude auth"}
+
+	if _, err := lookupClaudeBin("claude"); err != nil {
+		check.Status = DoctorWarn
+		check.Detail = "not checked: claude CLI not found"
+		return check
+	}
+
+	conn := probeClaudeConnection("claude", mcfRoot, ClaudeConnectionTimeout)
+	check.Detail = conn.Status
+	if conn.Detail != "" {
+		check.Detail += " (" + conn.Detail + ")"
+	}
+	if !conn.OK() {
+		check.Status = DoctorFail
+		check.Remediation = conn.Remediation
+	}
+	return check
+}
+
+// checkCommandPermissions verifies command files are readable, scripts are
+// executable, and nothing can be modified by other users
+func checkCommandPermissions(mcfRoot string) DoctorCheck {
+	check := DoctorCheck{Name: "Command permissions"}
+	dir := filepath.Join(mcfRoot, ".claude", "commands")
+
+	var unreadable, notExecutable, writable []string
+	modes := make(map[string]os.FileMode)
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+
+		f, err := os.Open(path)
+		if err != nil {
+			unreadable = append(unreadable, rel)
+			return nil
+		}
+		script := isScript(f, path)
+		f.Close()
+
+		mode := info.Mode().Perm()
+		fixed := mode &^ 0022
+		if script && mode&0100 == 0 {
+			notExecutable = append(notExecutable, rel)
+			fixed |= 0100
+		}
+		if mode&0022 != 0 {
+			writable = append(writable, rel)
+		}
+		if fixed != mode {
+			modes[path] = fixed
+		}
+		return nil
+	})
+
+	switch {
+	case len(unreadable) > 0:
+		check.Status = DoctorFail
+		check.Detail = "unreadable: " + strings.Join(unreadable, ", ")
+		check.Remediation = "chmod u+r the listed files in " + dir + "."
+	case len(notExecutable) > 0:
+		check.Status = DoctorWarn
+		check.Detail = "scripts not executable: " + strings.Join(notExecutable, ", ")
+		check.Remediation = "chmod u+x the listed files in " + dir + "."
+	case len(writable) > 0:
+		check.Status = DoctorWarn
+		check.Detail = "writable by other users: " + strings.Join(writable, ", ")
+		check.Remediation = "chmod go-w the listed files in " + dir + "."
+	default:
+		check.Detail = "ok"
+	}
+	if len(unreadable) == 0 && len(modes) > 0 {
+		check.repair = func() error { return chmodAll(modes) }
+	}
+	return check
+}
+
+// checkSecretFileModes warns when settings.json or config.yaml hold secrets
+// (keys named like tokens, keys or passwords) but other users can read them
+func checkSecretFileModes(mcfRoot string) DoctorCheck {
+	check := DoctorCheck{Name: "Secret file modes"}
+
+	var exposed []string
+	modes := make(map[string]os.FileMode)
+	for _, path := range []string{SettingsPath(mcfRoot), ConfigPath(mcfRoot)} {
+		info, err := os.Stat(path)
+		if err != nil || info.Mode().Perm()&0077 == 0 {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		// YAML is a superset of JSON, so one decoder reads both files
+		var content map[string]interface{}
+		if yaml.Unmarshal(data, &content) != nil || len(secretKeys(content, "")) == 0 {
+			continue
+		}
+		exposed = append(exposed, filepath.Base(path))
+		modes[path] = info.Mode().Perm() &^ 0077
+	}
+
+	if len(exposed) == 0 {
+		check.Detail = "ok"
+		return check
+	}
+
+	check.Status = DoctorWarn
+	check.Detail = "readable by other users: " + strings.Join(exposed, ", ")
+	check.Remediation = "chmod 600 the listed files in " + filepath.Join(mcfRoot, ".claude") + "."
+	check.repair = func() error { return chmodAll(modes) }
+	return check
+}
+
+// secretKeys lists the dot-notation keys holding non-empty secret-looking values
+func secretKeys(content map[string]interface{}, prefix string) []string {
+	var keys []string
+	for key, value := range content {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			keys = append(keys, secretKeys(v, prefix+key+".")...)
+		case string:
+			if v != "" && secretEnvPattern.MatchString(key) {
+				keys = append(keys, prefix+key)
+			}
+		}
+	}
+	return keys
+}
+
+// createRequiredDirs creates any missing .claude directories
+func createRequiredDirs(mcfRoot string) error {
+	for _, required := range RequiredDirs {
+		if err := os.MkdirAll(filepath.Join(mcfRoot, required), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", required, err)
+		}
+	}
+	return nil
+}
+
+// chmodAll applies the given file modes
+func chmodAll(modes map[string]os.FileMode) error {
+	var errs []error
+	for path, mode := range modes {
+		errs = append(errs, os.Chmod(path, mode))
+	}
+	return errors.Join(errs...)
+}
+
+// isScript reports whether a command file is a shell script rather than a prompt
+func isScript(f *os.File, path string) bool {
+	if strings.HasSuffix(path, ".sh") {
+		return true
+	}
+	head := make([]byte, 2)
+	n, _ := f.Read(head)
+	return string(head[:n]) == "#!"
+}
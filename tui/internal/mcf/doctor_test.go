@@ -0,0 +1,191 @@
+package mcf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// healthyInstall writes a complete install and puts a fake Claude CLI on PATH
+func healthyInstall(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	require.NoError(t, InitProject(root, false))
+	writeFile(t, root, ".claude/agents/orchestrator.md", "# Orchestrator")
+	writeFile(t, root, ".claude/commands/project/analyze.md", "# Analyze")
+	t.Setenv("PATH", filepath.Dir(fakeClaude(t, "echo OK")))
+	return root
+}
+
+// checkNamed returns the check with the given name
+func checkNamed(t *testing.T, checks []DoctorCheck, name string) DoctorCheck {
+	t.Helper()
+	for _, check := range checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	t.Fatalf("no %s check", name)
+	return DoctorCheck{}
+}
+
+func TestDiagnose(t *testing.T) {
+	t.Run("should pass a healthy install", func(t *testing.T) {
+		checks := Diagnose(healthyInstall(t))
+
+		for _, check := range checks {
+			assert.Equal(t, DoctorOK, check.Status, check.String())
+		}
+		assert.False(t, DoctorFailed(checks))
+	})
+
+	t.Run("should fail a missing structure and settings", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+
+		checks := Diagnose(t.TempDir())
+
+		assert.True(t, DoctorFailed(checks))
+		structure := checkNamed(t, checks, ".claude structure")
+		assert.Equal(t, DoctorFail, structure.Status)
+		assert.Contains(t, structure.Remediation, "mcf-tui init")
+		assert.Equal(t, DoctorFail, checkNamed(t, checks, "settings.json").Status)
+		claude := checkNamed(t, checks, "Claude CLI")
+		assert.Equal(t, DoctorFail, claude.Status)
+		assert.Contains(t, claude.Remediation, "npm install")
+		assert.Equal(t, DoctorWarn, checkNamed(t, checks, "Claude auth").Status, "Auth can't be checked without the CLI")
+	})
+
+	t.Run("should point invalid settings at config repair", func(t *testing.T) {
+		root := healthyInstall(t)
+		writeFile(t, root, ".claude/settings.json", `{"version": "1.0.0"}`)
+
+		settings := checkNamed(t, Diagnose(root), "settings.json")
+
+		assert.Equal(t, DoctorFail, settings.Status)
+		assert.Contains(t, settings.Detail, "env.ANTHROPIC_MODEL is missing")
+		assert.Contains(t, settings.Remediation, "config repair")
+	})
+
+	t.Run("should validate config.yaml only when present", func(t *testing.T) {
+		root := healthyInstall(t)
+		assert.Equal(t, DoctorOK, checkNamed(t, Diagnose(root), "config.yaml").Status)
+
+		writeFile(t, root, ".claude/config.yaml", "tui: [unclosed\n")
+		config := checkNamed(t, Diagnose(root), "config.yaml")
+
+		assert.Equal(t, DoctorFail, config.Status)
+		assert.Contains(t, config.Detail, "invalid YAML")
+	})
+
+	t.Run("should fail an unauthenticated Claude CLI", func(t *testing.T) {
+		root := healthyInstall(t)
+		t.Setenv("PATH", filepath.Dir(fakeClaude(t, "echo 'API Error: 401 Unauthorized' >&2; exit 1")))
+
+		auth := checkNamed(t, Diagnose(root), "Claude auth")
+
+		assert.Equal(t, DoctorFail, auth.Status)
+		assert.Contains(t, auth.Detail, ClaudeUnauthenticated)
+		assert.Contains(t, auth.Remediation, "/login")
+	})
+
+	t.Run("should warn about empty operation directories", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+		root := t.TempDir()
+		require.NoError(t, InitProject(root, false))
+
+		structure := checkNamed(t, Diagnose(root), ".claude structure")
+
+		assert.Equal(t, DoctorWarn, structure.Status)
+		assert.Contains(t, structure.Detail, "agents")
+	})
+
+	t.Run("should warn about script permissions", func(t *testing.T) {
+		root := healthyInstall(t)
+		writeFile(t, root, ".claude/commands/deploy.sh", "echo deploy")
+		writeFile(t, root, ".claude/commands/lint", "#!/bin/sh\necho lint")
+
+		permissions := checkNamed(t, Diagnose(root), "Command permissions")
+		assert.Equal(t, DoctorWarn, permissions.Status)
+		assert.Contains(t, permissions.Detail, "deploy.sh")
+		assert.Contains(t, permissions.Detail, "lint")
+		assert.NotContains(t, permissions.Detail, "analyze.md", "Prompts need not be executable")
+
+		for _, name := range []string{"deploy.sh", "lint"} {
+			require.NoError(t, os.Chmod(filepath.Join(root, ".claude", "commands", name), 0755))
+		}
+		require.NoError(t, os.Chmod(filepath.Join(root, ".claude", "commands", "project", "analyze.md"), 0666))
+
+		permissions = checkNamed(t, Diagnose(root), "Command permissions")
+		assert.Equal(t, DoctorWarn, permissions.Status)
+		assert.Contains(t, permissions.Detail, "writable by other users")
+		assert.Contains(t, permissions.Detail, "analyze.md")
+	})
+}
+
+func TestDoctorFix(t *testing.T) {
+	fixAll := func(t *testing.T, root string) []DoctorCheck {
+		t.Helper()
+		for _, check := range Diagnose(root) {
+			if check.Fixable() {
+				require.NoError(t, check.Fix(), check.Name)
+			}
+		}
+		return Diagnose(root)
+	}
+
+	t.Run("should recreate a missing install", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+		root := t.TempDir()
+
+		checks := fixAll(t, root)
+
+		assert.Equal(t, DoctorWarn, checkNamed(t, checks, ".claude structure").Status, "Only empty directories should remain")
+		assert.Equal(t, DoctorOK, checkNamed(t, checks, "settings.json").Status)
+		claude := checkNamed(t, checks, "Claude CLI")
+		assert.Equal(t, DoctorFail, claude.Status)
+		assert.False(t, claude.Fixable(), "Installing the CLI is left to the user")
+	})
+
+	t.Run("should repair settings and permissions idempotently", func(t *testing.T) {
+		root := healthyInstall(t)
+		writeFile(t, root, ".claude/settings.json", `{"version": "1.0.0", "env": {"ANTHROPIC_API_KEY": "sk-test"}}`)
+		writeFile(t, root, ".claude/commands/deploy.sh", "echo deploy")
+		require.NoError(t, os.Chmod(filepath.Join(root, ".claude", "commands", "project", "analyze.md"), 0666))
+
+		checks := Diagnose(root)
+		assert.Equal(t, DoctorWarn, checkNamed(t, checks, "Secret file modes").Status)
+		checks = fixAll(t, root)
+
+		for _, check := range checks {
+			assert.Equal(t, DoctorOK, check.Status, check.String())
+		}
+		info, err := os.Stat(SettingsPath(root))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		info, err = os.Stat(filepath.Join(root, ".claude", "commands", "deploy.sh"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0744), info.Mode().Perm())
+
+		settings, err := LoadRawSettings(root)
+		require.NoError(t, err)
+		assert.Equal(t, "sk-test", settings["env"].(map[string]interface{})["ANTHROPIC_API_KEY"], "Repair should keep existing values")
+
+		for _, check := range checks {
+			assert.False(t, check.Fixable(), "Nothing should be left to fix: %s", check.Name)
+		}
+	})
+
+	t.Run("should leave broken JSON for the user", func(t *testing.T) {
+		root := healthyInstall(t)
+		writeFile(t, root, ".claude/settings.json", "{")
+
+		settings := checkNamed(t, Diagnose(root), "settings.json")
+
+		assert.Equal(t, DoctorFail, settings.Status)
+		assert.False(t, settings.Fixable())
+		assert.Error(t, settings.Fix())
+	})
+}
@@ -0,0 +1,72 @@
+package mcf
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ParameterDef is an input a command declares in its frontmatter `parameters:` list.
+// An entry may be a bare name or a mapping with the fields below.
+type ParameterDef struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Required    bool   `yaml:"required,omitempty" json:"required,omitempty"`
+	Default     string `yaml:"default,omitempty" json:"default,omitempty"`
+}
+
+// UnmarshalYAML accepts `- target` as shorthand for `- name: target`
+func (p *ParameterDef) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		p.Name = node.Value
+		return nil
+	}
+	type plain ParameterDef
+	return node.Decode((*plain)(p))
+}
+
+// commandFrontmatter holds the frontmatter keys read from command files
+type commandFrontmatter struct {
+	Parameters []ParameterDef `yaml:"parameters"`
+}
+
+// splitFrontmatter returns the YAML between a leading `---` line and the next `---` line.
+// ok is false when the content has no frontmatter block.
+func splitFrontmatter(content string) (frontmatter string, ok bool) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	rest, found := strings.CutPrefix(content, "---\n")
+	if !found {
+		return "", false
+	}
+	if strings.HasPrefix(rest, "---") {
+		return "", true
+	}
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return "", false
+	}
+	return rest[:end], true
+}
+
+// parseCommandParameters reads the `parameters:` list from a command file's frontmatter.
+// Files without frontmatter declare no parameters.
+func parseCommandParameters(content string) ([]ParameterDef, error) {
+	frontmatter, ok := splitFrontmatter(content)
+	if !ok {
+		return nil, nil
+	}
+
+	var meta commandFrontmatter
+	if err := yaml.Unmarshal([]byte(frontmatter), &meta); err != nil {
+		return nil, fmt.Errorf("invalid frontmatter: %w", err)
+	}
+
+	params := meta.Parameters[:0]
+	for _, p := range meta.Parameters {
+		if p.Name != "" {
+			params = append(params, p)
+		}
+	}
+	return params, nil
+}
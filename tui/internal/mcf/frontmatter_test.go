@@ -0,0 +1,59 @@
+package mcf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommandParameters(t *testing.T) {
+	t.Run("should read declared parameters", func(t *testing.T) {
+		params, err := parseCommandParameters(`---
+description: Deploy the project
+parameters:
+  - name: environment
+    description: Target environment
+    required: true
+    default: staging
+  - dry-run
+---
+# Deploy
+`)
+
+		require.NoError(t, err)
+		assert.Equal(t, []ParameterDef{
+			{Name: "environment", Description: "Target environment", Required: true, Default: "staging"},
+			{Name: "dry-run"},
+		}, params)
+	})
+
+	t.Run("should return no parameters without frontmatter", func(t *testing.T) {
+		for _, content := range []string{"# Analyze\nparameters:\n  - x\n", "---\ndescription: unterminated\n", "---\n---\n"} {
+			params, err := parseCommandParameters(content)
+
+			require.NoError(t, err, content)
+			assert.Empty(t, params, content)
+		}
+	})
+
+	t.Run("should reject malformed YAML", func(t *testing.T) {
+		params, err := parseCommandParameters("---\nparameters: [unclosed\n---\n")
+
+		assert.Error(t, err)
+		assert.Empty(t, params)
+	})
+
+	t.Run("should attach parameters to discovered commands", func(t *testing.T) {
+		adapter := newTestAdapterWith(t, map[string]string{
+			".claude/commands/deploy.md": "---\ndescription: Deploy\nparameters:\n  - environment\n---\n",
+			".claude/commands/broken.md": "---\nparameters: {oops\n---\n# Broken\n",
+		})
+
+		commands := adapter.GetCommands()
+
+		assert.Equal(t, []ParameterDef{{Name: "environment"}}, commands["deploy"].Parameters)
+		require.Contains(t, commands, "broken", "A malformed frontmatter block should not hide the command")
+		assert.Empty(t, commands["broken"].Parameters)
+	})
+}
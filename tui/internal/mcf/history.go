@@ -0,0 +1,141 @@
+package mcf
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultHistorySize is how many runs are kept when tui.history_size is not set
+const DefaultHistorySize = 100
+
+// HistoryPath returns the file recording past command runs
+func HistoryPath(mcfRoot string) string {
+	return filepath.Join(mcfRoot, ".claude", "logs", "runner-history.json")
+}
+
+// HistoryEntry records one finished command run, without its output
+type HistoryEntry struct {
+	Command    string    `json:"command"`
+	Args       []string  `json:"args"`
+	Success    bool      `json:"success"`
+	Cancelled  bool      `json:"cancelled,omitempty"`
+	ExitCode   int       `json:"exitCode"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"durationMs"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// NewHistoryEntry builds an entry for a finished command
+func NewHistoryEntry(command string, args []string, result *CommandResult, started time.Time, duration time.Duration) HistoryEntry {
+	return HistoryEntry{
+		Command:    command,
+		Args:       append([]string{}, args...),
+		Success:    result.Success,
+		Cancelled:  result.Cancelled,
+		ExitCode:   result.Code,
+		Error:      result.Error,
+		DurationMs: duration.Milliseconds(),
+		Timestamp:  started.UTC(),
+	}
+}
+
+// Duration returns how long the run took
+func (e HistoryEntry) Duration() time.Duration {
+	return time.Duration(e.DurationMs) * time.Millisecond
+}
+
+// History is a capped, file-backed list of past runs, oldest first
+type History struct {
+	mu      sync.Mutex
+	path    string
+	limit   int
+	entries []HistoryEntry
+}
+
+// LoadHistory reads the history file at path, keeping at most limit entries.
+// A missing file is an empty history; an unreadable one is reported and replaced on the next Add.
+func LoadHistory(path string, limit int) (*History, error) {
+	if limit <= 0 {
+		limit = DefaultHistorySize
+	}
+	h := &History{path: path, limit: limit}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return h, err
+	}
+	if err := json.Unmarshal(data, &h.entries); err != nil {
+		return h, err
+	}
+	h.trim()
+	return h, nil
+}
+
+// Add records a run, trimming the oldest entries past the limit, and saves the file
+func (h *History) Add(entry HistoryEntry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = append(h.entries, entry)
+	h.trim()
+	return h.save()
+}
+
+// Entries returns the recorded runs, newest first
+func (h *History) Entries() []HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entries := make([]HistoryEntry, len(h.entries))
+	for i, entry := range h.entries {
+		entries[len(h.entries)-1-i] = entry
+	}
+	return entries
+}
+
+// ForCommand returns the recorded runs of one command, newest first
+func (h *History) ForCommand(command string) []HistoryEntry {
+	var entries []HistoryEntry
+	for _, entry := range h.Entries() {
+		if entry.Command == command {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func (h *History) trim() {
+	if excess := len(h.entries) - h.limit; excess > 0 {
+		h.entries = append([]HistoryEntry(nil), h.entries[excess:]...)
+	}
+}
+
+func (h *History) save() error {
+	data, err := json.MarshalIndent(h.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(h.path, data, 0644)
+}
+
+// historyLimit reads tui.history_size from the MCF settings
+func historyLimit(mcfRoot string) int {
+	settings, err := LoadRawSettings(mcfRoot)
+	if err != nil {
+		return DefaultHistorySize
+	}
+	tuiSettings, _ := settings["tui"].(map[string]interface{})
+	if size, ok := tuiSettings["history_size"].(float64); ok && size > 0 {
+		return int(size)
+	}
+	return DefaultHistorySize
+}
@@ -0,0 +1,86 @@
+package mcf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	entry := func(command string) HistoryEntry {
+		return HistoryEntry{Command: command, Args: []string{}, Success: true, Timestamp: time.Now().UTC()}
+	}
+
+	t.Run("should trim the oldest entries past the limit and persist", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "logs", "runner-history.json")
+		history, err := LoadHistory(path, 2)
+		require.NoError(t, err)
+
+		for _, name := range []string{"one", "two", "three"} {
+			require.NoError(t, history.Add(entry(name)))
+		}
+
+		reloaded, err := LoadHistory(path, 2)
+		require.NoError(t, err)
+		var names []string
+		for _, e := range reloaded.Entries() {
+			names = append(names, e.Command)
+		}
+		assert.Equal(t, []string{"three", "two"}, names, "Newest first, oldest trimmed")
+	})
+
+	t.Run("should filter by command", func(t *testing.T) {
+		history, err := LoadHistory(filepath.Join(t.TempDir(), "history.json"), 10)
+		require.NoError(t, err)
+		require.NoError(t, history.Add(entry("a")))
+		require.NoError(t, history.Add(entry("b")))
+		require.NoError(t, history.Add(entry("a")))
+
+		assert.Len(t, history.ForCommand("a"), 2)
+		assert.Empty(t, history.ForCommand("c"))
+	})
+
+	t.Run("should report a damaged file and start empty", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "history.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+
+		history, err := LoadHistory(path, 10)
+
+		assert.Error(t, err)
+		require.NotNil(t, history)
+		assert.Empty(t, history.Entries())
+	})
+}
+
+func TestAdapterRecordsHistory(t *testing.T) {
+	adapter := newTestAdapterWith(t, map[string]string{
+		".claude/settings.json":      `{"version": "1.0.0", "tui": {"history_size": 2}}`,
+		".claude/commands/ci/ok.md":  "command: echo ok\n# Runs in bash",
+		".claude/commands/ci/bad.md": "command: exit 4\n# Runs in bash",
+	})
+
+	_, err := adapter.ExecuteCommand("ci:ok", []string{"--fast"})
+	require.NoError(t, err)
+	_, err = adapter.ExecuteCommand("ci:bad", nil)
+	require.NoError(t, err)
+	_, err = adapter.ExecuteCommand("missing", nil)
+	require.NoError(t, err)
+
+	reloaded, err := LoadHistory(HistoryPath(adapter.mcfRoot), 10)
+	require.NoError(t, err)
+	entries := reloaded.Entries()
+	require.Len(t, entries, 2, "Unknown commands are not recorded")
+	assert.Equal(t, "ci:bad", entries[0].Command)
+	assert.False(t, entries[0].Success)
+	assert.Equal(t, 4, entries[0].ExitCode)
+	assert.Equal(t, "ci:ok", entries[1].Command)
+	assert.Equal(t, []string{"--fast"}, entries[1].Args)
+
+	_, err = adapter.ExecuteCommand("ci:ok", nil)
+	require.NoError(t, err)
+	assert.Len(t, adapter.History().Entries(), 2, "tui.history_size caps the history")
+}
@@ -0,0 +1,107 @@
+package mcf
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// indexVersion is bumped whenever the cached entry layout changes
+const indexVersion = 2
+
+// IndexPath returns the location of the cached agent/command index
+func IndexPath(mcfRoot string) string {
+	return filepath.Join(mcfRoot, ".claude", "cache", "index.json")
+}
+
+// indexEntry caches one parsed operation file, keyed by its path relative to the MCF root
+type indexEntry struct {
+	ModTime time.Time `json:"modTime"`
+	Size    int64     `json:"size"`
+	Agent   *Agent    `json:"agent,omitempty"`
+	Command *Command  `json:"command,omitempty"`
+}
+
+// operationIndex lets discovery skip re-parsing files that have not changed
+type operationIndex struct {
+	Version int                   `json:"version"`
+	Entries map[string]indexEntry `json:"entries"`
+
+	seen   map[string]bool
+	dirty  bool
+	hits   int
+	misses int
+}
+
+func newOperationIndex() *operationIndex {
+	return &operationIndex{
+		Version: indexVersion,
+		Entries: map[string]indexEntry{},
+		seen:    map[string]bool{},
+	}
+}
+
+// loadOperationIndex reads the cached index; a missing, unreadable or outdated
+// cache yields an empty index so everything is re-scanned
+func loadOperationIndex(mcfRoot string) *operationIndex {
+	data, err := os.ReadFile(IndexPath(mcfRoot))
+	if err != nil {
+		return newOperationIndex()
+	}
+
+	index := newOperationIndex()
+	if err := json.Unmarshal(data, index); err != nil || index.Version != indexVersion || index.Entries == nil {
+		return newOperationIndex()
+	}
+	return index
+}
+
+// lookup returns the cached entry for rel if the file is unchanged since it was indexed
+func (x *operationIndex) lookup(rel string, info fs.FileInfo) (indexEntry, bool) {
+	x.seen[rel] = true
+
+	entry, ok := x.Entries[rel]
+	if ok && entry.ModTime.Equal(info.ModTime()) && entry.Size == info.Size() {
+		x.hits++
+		return entry, true
+	}
+	x.misses++
+	return indexEntry{}, false
+}
+
+// store records a freshly parsed file
+func (x *operationIndex) store(rel string, info fs.FileInfo, entry indexEntry) {
+	entry.ModTime = info.ModTime()
+	entry.Size = info.Size()
+	x.Entries[rel] = entry
+	x.dirty = true
+}
+
+// save drops entries for files that no longer exist and writes the index if it changed
+func (x *operationIndex) save(mcfRoot string) error {
+	for rel := range x.Entries {
+		if !x.seen[rel] {
+			delete(x.Entries, rel)
+			x.dirty = true
+		}
+	}
+	if !x.dirty {
+		return nil
+	}
+
+	path := IndexPath(mcfRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(x)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	x.dirty = false
+	return nil
+}
@@ -0,0 +1,83 @@
+package mcf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationIndex(t *testing.T) {
+	t.Run("should write the index on the first scan", func(t *testing.T) {
+		adapter := newTestAdapter(t)
+
+		assert.Equal(t, 0, adapter.index.hits)
+		assert.Equal(t, 2, adapter.index.misses)
+		assert.FileExists(t, IndexPath(adapter.mcfRoot))
+	})
+
+	t.Run("should load unchanged files from the cache", func(t *testing.T) {
+		first := newTestAdapter(t)
+
+		second, err := NewMCFAdapter(first.mcfRoot)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, second.index.hits)
+		assert.Equal(t, 0, second.index.misses)
+		require.Contains(t, second.GetCommands(), "project:analyze")
+		assert.Equal(t, "Analyze", second.GetCommands()["project:analyze"].Description)
+		require.Len(t, second.GetAgents(), 1)
+		assert.Equal(t, "orchestrator", second.GetAgents()[0].Name)
+	})
+
+	t.Run("should re-parse a file that changed", func(t *testing.T) {
+		first := newTestAdapter(t)
+		path := filepath.Join(first.mcfRoot, ".claude", "commands", "project", "analyze.md")
+		require.NoError(t, os.WriteFile(path, []byte("# Analyze the whole project"), 0644))
+		later := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(path, later, later))
+
+		second, err := NewMCFAdapter(first.mcfRoot)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, second.index.hits)
+		assert.Equal(t, 1, second.index.misses)
+		assert.Equal(t, "Analyze the whole project", second.GetCommands()["project:analyze"].Description)
+	})
+
+	t.Run("should drop entries for removed files", func(t *testing.T) {
+		first := newTestAdapterWith(t, map[string]string{".claude/commands/project/deploy.md": "# Deploy"})
+		require.NoError(t, os.Remove(filepath.Join(first.mcfRoot, ".claude", "commands", "project", "deploy.md")))
+
+		second, err := NewMCFAdapter(first.mcfRoot)
+		require.NoError(t, err)
+
+		assert.NotContains(t, second.GetCommands(), "project:deploy")
+		assert.Len(t, loadOperationIndex(first.mcfRoot).Entries, 2)
+	})
+
+	t.Run("should ignore the cache when reindexing", func(t *testing.T) {
+		adapter := newTestAdapter(t)
+
+		require.NoError(t, adapter.Reindex())
+
+		assert.Equal(t, 0, adapter.index.hits)
+		assert.Equal(t, 2, adapter.index.misses)
+		assert.Len(t, adapter.GetCommands(), 1)
+		assert.Len(t, adapter.GetAgents(), 1)
+	})
+
+	t.Run("should fall back to a full scan on a corrupt cache", func(t *testing.T) {
+		first := newTestAdapter(t)
+		require.NoError(t, os.WriteFile(IndexPath(first.mcfRoot), []byte("{broken"), 0644))
+
+		second, err := NewMCFAdapter(first.mcfRoot)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, second.index.misses)
+		assert.Len(t, second.GetCommands(), 1)
+	})
+}
@@ -0,0 +1,162 @@
+package mcf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Invocation is the fully resolved process a command runs as. Execution builds its
+// exec.Cmd from an Invocation, so a preview shows exactly what will run.
+type Invocation struct {
+	Path string   // executable
+	Args []string // arguments after the executable
+	Dir  string   // working directory; empty means the current directory
+	Env  []string // KEY=value pairs added on top of the inherited environment
+
+	// Simulated is set for commands with nothing to run; they get a canned response
+	Simulated bool
+}
+
+// secretEnvPattern matches variable names whose values are hidden in previews
+var secretEnvPattern = regexp.MustCompile(`(?i)(TOKEN|KEY|SECRET|PASSWORD)`)
+
+// shellSafe matches words that need no quoting
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// cancelWaitDelay bounds how long a cancelled command may hold its output pipes open
+const cancelWaitDelay = 2 * time.Second
+
+// Cmd builds the exec.Cmd for this invocation. When ctx can be cancelled, cancelling
+// it kills the process together with any children it started.
+func (i Invocation) Cmd(ctx context.Context) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, i.Path, i.Args...)
+	cmd.Dir = i.Dir
+	if ctx.Done() != nil {
+		killProcessGroup(cmd)
+		cmd.WaitDelay = cancelWaitDelay
+	}
+	if len(i.Env) > 0 {
+		cmd.Env = append(os.Environ(), i.Env...)
+	}
+	return cmd
+}
+
+// RedactedEnv returns Env with secret-looking values replaced by ***
+func (i Invocation) RedactedEnv() []string {
+	env := make([]string, len(i.Env))
+	for idx, pair := range i.Env {
+		key, _, _ := strings.Cut(pair, "=")
+		if secretEnvPattern.MatchString(key) {
+			pair = key + "=***"
+		}
+		env[idx] = pair
+	}
+	return env
+}
+
+// CommandLine renders the executable and arguments as a shell-quoted line
+func (i Invocation) CommandLine() string {
+	words := make([]string, 0, len(i.Args)+1)
+	words = append(words, shellQuote(i.Path))
+	for _, arg := range i.Args {
+		words = append(words, shellQuote(arg))
+	}
+	return strings.Join(words, " ")
+}
+
+// String describes the invocation over several lines: command, directory and added environment
+func (i Invocation) String() string {
+	if i.Simulated {
+		return "(simulated: no process is started)"
+	}
+
+	dir := i.Dir
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Command: %s\n", i.CommandLine())
+	fmt.Fprintf(&b, "Dir: %s\n", dir)
+	for _, pair := range i.RedactedEnv() {
+		fmt.Fprintf(&b, "Env: %s\n", pair)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// shellQuote single-quotes s unless it consists only of safe characters
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// PreviewCommand resolves how commandName would run with args, without running it.
+// It follows the same routing as ExecuteCommand.
+func (m *MCFAdapter) PreviewCommand(commandName string, args []string) (Invocation, error) {
+	cmd, exists := m.commands[commandName]
+	if !exists {
+		return Invocation{}, fmt.Errorf("command '%s' not found", commandName)
+	}
+
+	content, err := os.ReadFile(cmd.Path)
+	if err != nil {
+		return Invocation{Simulated: true}, nil
+	}
+	commandContent := string(content)
+
+	if strings.HasPrefix(commandContent, "---") {
+		return m.claudeInvocation(cmd, args), nil
+	}
+	if strings.Contains(commandContent, "bash") || strings.Contains(commandContent, "shell") {
+		if invocation, err := shellInvocation(commandContent); err == nil {
+			return invocation, nil
+		}
+	}
+	return Invocation{Simulated: true}, nil
+}
+
+// claudeInvocation runs cmd as a Claude slash command with the proxy environment (matching claude.sh)
+func (m *MCFAdapter) claudeInvocation(cmd *Command, args []string) Invocation {
+	claudeCommand := fmt.Sprintf("/%s", cmd.Name)
+	if len(args) > 0 {
+		claudeCommand += " " + strings.Join(args, " ")
+	}
+
+	return Invocation{
+		Path: m.claudeBin,
+		Args: []string{"--dangerously-skip-permissions", "-p", claudeCommand},
+		Dir:  m.mcfRoot,
+		Env:  claudeEnvOverrides(),
+	}
+}
+
+// shellInvocation extracts the shell command from a command file and runs it via bash
+func shellInvocation(commandContent string) (Invocation, error) {
+	// Extract shell command from the content (simplified)
+	lines := strings.Split(commandContent, "\n")
+	var shellCmd string
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "command:") || strings.HasPrefix(line, "exec:") {
+			shellCmd = strings.TrimSpace(strings.SplitN(line, ":", 2)[1])
+			break
+		}
+		if strings.Contains(line, "bash") || strings.Contains(line, "sh") {
+			shellCmd = line
+			break
+		}
+	}
+
+	if shellCmd == "" {
+		return Invocation{}, fmt.Errorf("no shell command found")
+	}
+	return Invocation{Path: "bash", Args: []string{"-c", shellCmd}}, nil
+}
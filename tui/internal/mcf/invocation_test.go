@@ -0,0 +1,84 @@
+package mcf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewCommand(t *testing.T) {
+	newAdapter := func(t *testing.T) *MCFAdapter {
+		return newTestAdapterWith(t, map[string]string{
+			".claude/commands/review.md":  "---\ndescription: Reviews\n---\n",
+			".claude/commands/ci/lint.md": "command: echo 'lint ok'\n# Runs in bash",
+		})
+	}
+
+	t.Run("should match what the Claude CLI actually receives", func(t *testing.T) {
+		adapter := newAdapter(t)
+		// Echo argv, working directory and one added variable, one per line
+		adapter.claudeBin = fakeClaude(t, `printf '%s\n' "$@"; pwd; echo "$ANTHROPIC_BASE_URL"`)
+
+		invocation, err := adapter.PreviewCommand("review", []string{"main", "--strict"})
+		require.NoError(t, err)
+		result, err := adapter.ExecuteCommand("review", []string{"main", "--strict"})
+		require.NoError(t, err)
+		require.True(t, result.Success, result.Error)
+
+		dir, err := filepath.EvalSymlinks(invocation.Dir)
+		require.NoError(t, err)
+		expected := append(append([]string{}, invocation.Args...), dir, "http://localhost:4141")
+		assert.Equal(t, strings.Join(expected, "\n")+"\n", result.Stdout)
+		assert.Equal(t, []string{"--dangerously-skip-permissions", "-p", "/review main --strict"}, invocation.Args)
+		assert.Contains(t, invocation.Env, "ANTHROPIC_BASE_URL=http://localhost:4141")
+	})
+
+	t.Run("should resolve shell commands", func(t *testing.T) {
+		adapter := newAdapter(t)
+
+		invocation, err := adapter.PreviewCommand("ci:lint", nil)
+		require.NoError(t, err)
+		result, err := adapter.ExecuteCommand("ci:lint", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "bash -c 'echo '\\''lint ok'\\'''", invocation.CommandLine())
+		assert.Equal(t, "lint ok\n", result.Stdout)
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		assert.Contains(t, invocation.String(), "Dir: "+cwd)
+	})
+
+	t.Run("should report simulated commands", func(t *testing.T) {
+		adapter := newAdapter(t)
+
+		invocation, err := adapter.PreviewCommand("project:analyze", nil)
+		require.NoError(t, err)
+
+		assert.True(t, invocation.Simulated)
+		assert.Contains(t, invocation.String(), "simulated")
+	})
+
+	t.Run("should reject unknown commands", func(t *testing.T) {
+		_, err := newAdapter(t).PreviewCommand("missing", nil)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestInvocationString(t *testing.T) {
+	invocation := Invocation{
+		Path: "claude",
+		Args: []string{"-p", "/review it's done"},
+		Dir:  "/work",
+		Env:  []string{"ANTHROPIC_AUTH_TOKEN=dummy", "ANTHROPIC_MODEL=claude-3.5-sonnet"},
+	}
+
+	assert.Equal(t, "Command: claude -p '/review it'\\''s done'\n"+
+		"Dir: /work\n"+
+		"Env: ANTHROPIC_AUTH_TOKEN=***\n"+
+		"Env: ANTHROPIC_MODEL=claude-3.5-sonnet", invocation.String())
+}
@@ -0,0 +1,152 @@
+package mcf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// DefaultMaxOutputBytes caps how much command output is kept in memory
+const DefaultMaxOutputBytes = 1 << 20 // 1 MiB
+
+// cappedOutput is an io.Writer that keeps at most limit bytes in memory.
+// Once the limit is exceeded the full stream is spilled to a file in spillDir
+// so nothing is lost, while the process keeps running to completion.
+// It is safe for concurrent writers, so stdout and stderr can share one.
+type cappedOutput struct {
+	mu       sync.Mutex
+	limit    int
+	spillDir string
+
+	buf      bytes.Buffer
+	total    int64
+	spill    *os.File
+	spillErr error
+}
+
+func newCappedOutput(limit int, spillDir string) *cappedOutput {
+	return &cappedOutput{limit: limit, spillDir: spillDir}
+}
+
+func (c *cappedOutput) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.total += int64(len(p))
+
+	if c.spill == nil && c.spillErr == nil && c.buf.Len()+len(p) > c.limit && c.spillDir != "" {
+		c.startSpill()
+	}
+	if c.spill != nil {
+		if _, err := c.spill.Write(p); err != nil {
+			c.spillErr = err
+			c.spill.Close()
+			c.spill = nil
+		}
+	}
+
+	if room := c.limit - c.buf.Len(); room > 0 {
+		c.buf.Write(p[:min(room, len(p))])
+	}
+
+	// Always report success so the child process is never blocked on a full pipe
+	return len(p), nil
+}
+
+// startSpill opens the spill file and copies what has been buffered so far
+func (c *cappedOutput) startSpill() {
+	if err := os.MkdirAll(c.spillDir, 0755); err != nil {
+		c.spillErr = err
+		return
+	}
+	f, err := os.CreateTemp(c.spillDir, "output-*.log")
+	if err != nil {
+		c.spillErr = err
+		return
+	}
+	if _, err := f.Write(c.buf.Bytes()); err != nil {
+		f.Close()
+		c.spillErr = err
+		return
+	}
+	c.spill = f
+}
+
+// Truncated reports whether output beyond the limit was dropped from memory
+func (c *cappedOutput) Truncated() bool {
+	return c.total > int64(c.limit)
+}
+
+// SpillPath returns the file holding the full output, or "" if none was written
+func (c *cappedOutput) SpillPath() string {
+	if c.spill == nil {
+		return ""
+	}
+	return c.spill.Name()
+}
+
+// Close finishes the spill file, if any
+func (c *cappedOutput) Close() error {
+	if c.spill == nil {
+		return nil
+	}
+	return c.spill.Close()
+}
+
+// String returns the captured output with a truncation marker when needed
+func (c *cappedOutput) String() string {
+	if !c.Truncated() {
+		return c.buf.String()
+	}
+
+	marker := fmt.Sprintf("\n... output truncated (showing %d of %d bytes)", c.buf.Len(), c.total)
+	if path := c.SpillPath(); path != "" {
+		marker += "; full output saved to " + path
+	}
+	return c.buf.String() + marker
+}
+
+// maxLineBytes bounds a partial line held by lineWriter before it is emitted anyway
+const maxLineBytes = 64 << 10
+
+// OutputLine is one line of output from a running command
+type OutputLine struct {
+	Stream string // "stdout" or "stderr"
+	Text   string // line without its trailing newline
+}
+
+// OutputHandler receives output lines as a command produces them. It is called
+// from the goroutines copying stdout and stderr, so it must be safe for concurrent use.
+type OutputHandler func(OutputLine)
+
+// lineWriter splits one output stream into lines and passes each to handle
+type lineWriter struct {
+	stream  string
+	handle  OutputHandler
+	partial []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.handle(OutputLine{Stream: w.stream, Text: string(w.partial[:i])})
+		w.partial = w.partial[i+1:]
+	}
+	if len(w.partial) > maxLineBytes {
+		w.Flush()
+	}
+	return len(p), nil
+}
+
+// Flush emits any trailing text that did not end in a newline
+func (w *lineWriter) Flush() {
+	if len(w.partial) > 0 {
+		w.handle(OutputLine{Stream: w.stream, Text: string(w.partial)})
+		w.partial = nil
+	}
+}
@@ -0,0 +1,156 @@
+package mcf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCappedOutput(t *testing.T) {
+	t.Run("should keep small output intact", func(t *testing.T) {
+		output := newCappedOutput(16, t.TempDir())
+		output.Write([]byte("hello"))
+		require.NoError(t, output.Close())
+
+		assert.False(t, output.Truncated())
+		assert.Equal(t, "hello", output.String())
+		assert.Empty(t, output.SpillPath())
+	})
+
+	t.Run("should truncate oversized command output and save it in full", func(t *testing.T) {
+		adapter := newTestAdapter(t)
+		adapter.maxOutputBytes = 1024
+		adapter.outputDir = t.TempDir()
+
+		// 4 MiB of output, well over the cap
+		result, err := adapter.executeShellCommand(context.Background(), "command: head -c 4194304 /dev/zero | tr '\\0' x", nil, nil)
+		require.NoError(t, err)
+
+		assert.True(t, result.Success, "Process should still run to completion")
+		assert.Less(t, len(result.Output), 2048, "Captured output should stay near the cap")
+		assert.Contains(t, result.Output, "output truncated (showing 1024 of 4194304 bytes)")
+
+		path := regexp.MustCompile(`saved to (\S+)`).FindStringSubmatch(result.Output)
+		require.Len(t, path, 2)
+		info, err := os.Stat(path[1])
+		require.NoError(t, err)
+		assert.Equal(t, int64(4194304), info.Size(), "Spill file should hold the full output")
+	})
+	t.Run("should capture stdout and stderr separately", func(t *testing.T) {
+		adapter := newTestAdapter(t)
+
+		result, err := adapter.executeShellCommand(context.Background(), "command: echo out; echo warn >&2", nil, nil)
+		require.NoError(t, err)
+
+		assert.True(t, result.Success, "Stderr output alone should not mark failure")
+		assert.Equal(t, "out\n", result.Stdout)
+		assert.Equal(t, "warn\n", result.Stderr)
+		assert.Contains(t, result.Output, "out")
+		assert.Contains(t, result.Output, "warn")
+	})
+
+	t.Run("should take the code from the exit status", func(t *testing.T) {
+		adapter := newTestAdapter(t)
+
+		result, err := adapter.executeShellCommand(context.Background(), "command: echo oops >&2; exit 3", nil, nil)
+		require.NoError(t, err)
+
+		assert.False(t, result.Success)
+		assert.Equal(t, 3, result.Code)
+		assert.Equal(t, "oops\n", result.Stderr)
+	})
+}
+
+func TestExecuteCommandStreaming(t *testing.T) {
+	t.Run("should deliver lines while the command is still running", func(t *testing.T) {
+		adapter := newTestAdapterWith(t, map[string]string{
+			".claude/commands/slow.md": "command: echo first; sleep 2; echo second\n# Runs in bash",
+		})
+
+		lines := make(chan OutputLine, 10)
+		done := make(chan *CommandResult, 1)
+		go func() {
+			result, _ := adapter.ExecuteCommandStreaming(context.Background(), "slow", nil, func(line OutputLine) { lines <- line })
+			done <- result
+		}()
+
+		select {
+		case line := <-lines:
+			assert.Equal(t, OutputLine{Stream: "stdout", Text: "first"}, line)
+		case <-time.After(time.Second):
+			t.Fatal("First line should arrive before the command finishes")
+		}
+		assert.Empty(t, done, "Command should still be running")
+
+		result := <-done
+		assert.Equal(t, OutputLine{Stream: "stdout", Text: "second"}, <-lines)
+		assert.Equal(t, "first\nsecond\n", result.Output, "Final result keeps the full output")
+	})
+
+	t.Run("should label streams and flush a trailing partial line", func(t *testing.T) {
+		adapter := newTestAdapterWith(t, map[string]string{
+			".claude/commands/mixed.md": "command: echo warn >&2; printf 'a\\nb'\n# Runs in bash",
+		})
+
+		var mu sync.Mutex
+		var got []OutputLine
+		_, err := adapter.ExecuteCommandStreaming(context.Background(), "mixed", nil, func(line OutputLine) {
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, line)
+		})
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []OutputLine{
+			{Stream: "stderr", Text: "warn"},
+			{Stream: "stdout", Text: "a"},
+			{Stream: "stdout", Text: "b"},
+		}, got)
+	})
+}
+
+func TestExecuteCommandStreaming_Cancel(t *testing.T) {
+	pidFile := filepath.Join(t.TempDir(), "child.pid")
+	adapter := newTestAdapterWith(t, map[string]string{
+		".claude/commands/hang.md": "command: sleep 30 & echo $! > " + pidFile + "; echo started; wait\n# Runs in bash",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{}, 1)
+	done := make(chan *CommandResult, 1)
+	go func() {
+		result, _ := adapter.ExecuteCommandStreaming(ctx, "hang", nil, func(OutputLine) { started <- struct{}{} })
+		done <- result
+	}()
+
+	<-started
+	cancel()
+
+	var result *CommandResult
+	select {
+	case result = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Cancelled command should return promptly")
+	}
+	assert.True(t, result.Cancelled)
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "cancelled")
+
+	// The backgrounded child must not keep running once its parent is cancelled
+	data, err := os.ReadFile(pidFile)
+	require.NoError(t, err)
+	statPath := filepath.Join("/proc", strings.TrimSpace(string(data)), "stat")
+	assert.Eventually(t, func() bool {
+		stat, err := os.ReadFile(statPath)
+		// Gone, or dead and awaiting reaping by init
+		return err != nil || strings.Fields(string(stat))[2] == "Z"
+	}, 2*time.Second, 20*time.Millisecond, "Child should not outlive the cancelled command")
+}
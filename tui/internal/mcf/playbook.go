@@ -0,0 +1,126 @@
+package mcf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PlaybookStep is one queued operation with its arguments
+type PlaybookStep struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args,omitempty"`
+}
+
+// Playbook is a reusable sequence of operations
+type Playbook struct {
+	Name            string         `json:"name"`
+	ContinueOnError bool           `json:"continueOnError"`
+	Steps           []PlaybookStep `json:"steps"`
+}
+
+// StepResult is the outcome of a single playbook step
+type StepResult struct {
+	Step    PlaybookStep
+	Result  *CommandResult
+	Err     error
+	Skipped bool // not run because an earlier step failed or the run was cancelled
+}
+
+// Succeeded reports whether the step ran and succeeded
+func (r StepResult) Succeeded() bool {
+	return !r.Skipped && r.Err == nil && r.Result != nil && r.Result.Success
+}
+
+// RunPlaybook executes steps in order, stopping at the first failure unless ContinueOnError is set
+func (m *MCFAdapter) RunPlaybook(pb Playbook) []StepResult {
+	return m.RunPlaybookContext(context.Background(), pb, nil)
+}
+
+// RunPlaybookContext is RunPlaybook with cancellation and progress. onStep, when
+// set, receives each result as its step finishes. Cancelling ctx kills the
+// running step; the steps after it are reported as skipped.
+func (m *MCFAdapter) RunPlaybookContext(ctx context.Context, pb Playbook, onStep func(StepResult)) []StepResult {
+	results := make([]StepResult, 0, len(pb.Steps))
+	failed := false
+
+	for _, step := range pb.Steps {
+		stepResult := StepResult{Step: step, Skipped: true}
+		if ctx.Err() == nil && (!failed || pb.ContinueOnError) {
+			result, err := m.ExecuteCommandStreaming(ctx, step.Command, step.Args, nil)
+			stepResult = StepResult{Step: step, Result: result, Err: err}
+			if !stepResult.Succeeded() {
+				failed = true
+			}
+		}
+		results = append(results, stepResult)
+		if onStep != nil {
+			onStep(stepResult)
+		}
+	}
+
+	if m.logger != nil {
+		m.logger.Info("Playbook finished", "name", pb.Name, "steps", len(pb.Steps), "failed", failed)
+	}
+
+	return results
+}
+
+// PlaybookDir returns where playbooks are stored for an MCF root
+func PlaybookDir(mcfRoot string) string {
+	return filepath.Join(mcfRoot, ".claude", "playbooks")
+}
+
+// SavePlaybook writes pb to .claude/playbooks/<name>.json and returns the path
+func (m *MCFAdapter) SavePlaybook(pb Playbook) (string, error) {
+	if err := validatePlaybookName(pb.Name); err != nil {
+		return "", err
+	}
+
+	dir := PlaybookDir(m.mcfRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(pb, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, pb.Name+".json")
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// LoadPlaybook reads a saved playbook by name
+func (m *MCFAdapter) LoadPlaybook(name string) (Playbook, error) {
+	var pb Playbook
+	if err := validatePlaybookName(name); err != nil {
+		return pb, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(PlaybookDir(m.mcfRoot), name+".json"))
+	if err != nil {
+		return pb, err
+	}
+	if err := json.Unmarshal(data, &pb); err != nil {
+		return pb, fmt.Errorf("invalid playbook %s: %w", name, err)
+	}
+	if pb.Name == "" {
+		pb.Name = name
+	}
+	return pb, nil
+}
+
+// validatePlaybookName rejects names that would escape the playbooks directory
+func validatePlaybookName(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid playbook name %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package mcf
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPlaybookAdapter has commands "ok" and "fail" backed by a fake Claude CLI
+func newPlaybookAdapter(t *testing.T) *MCFAdapter {
+	t.Helper()
+	adapter := newTestAdapterWith(t, map[string]string{
+		".claude/commands/ok.md":   "---\ndescription: Succeeds\n---\n",
+		".claude/commands/fail.md": "---\ndescription: Fails\n---\n",
+	})
+	adapter.claudeBin = fakeClaude(t, `case "$3" in /fail*) echo boom; exit 1;; *) echo done;; esac`)
+	return adapter
+}
+
+func TestRunPlaybook(t *testing.T) {
+	steps := []PlaybookStep{{Command: "ok"}, {Command: "fail"}, {Command: "ok", Args: []string{"again"}}}
+
+	t.Run("should stop after the first failure by default", func(t *testing.T) {
+		adapter := newPlaybookAdapter(t)
+
+		results := adapter.RunPlaybook(Playbook{Name: "stop", Steps: steps})
+
+		require.Len(t, results, 3)
+		assert.True(t, results[0].Succeeded())
+		assert.False(t, results[1].Succeeded())
+		assert.False(t, results[1].Skipped)
+		assert.True(t, results[2].Skipped, "Steps after a failure should be skipped")
+	})
+
+	t.Run("should run every step when continuing on failure", func(t *testing.T) {
+		adapter := newPlaybookAdapter(t)
+
+		results := adapter.RunPlaybook(Playbook{Name: "continue", ContinueOnError: true, Steps: steps})
+
+		require.Len(t, results, 3)
+		assert.False(t, results[1].Succeeded())
+		assert.True(t, results[2].Succeeded(), "Later steps should still run")
+	})
+
+	t.Run("should report each step and skip the rest once cancelled", func(t *testing.T) {
+		adapter := newPlaybookAdapter(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var reported []StepResult
+		results := adapter.RunPlaybookContext(ctx, Playbook{Name: "cancel", ContinueOnError: true, Steps: steps}, func(r StepResult) {
+			reported = append(reported, r)
+			cancel()
+		})
+
+		require.Len(t, results, 3)
+		assert.Equal(t, results, reported, "Every step should be reported as it finishes")
+		assert.True(t, results[0].Succeeded())
+		assert.True(t, results[1].Skipped, "Steps after a cancel should not run")
+		assert.True(t, results[2].Skipped)
+	})
+}
+
+func TestSavePlaybook(t *testing.T) {
+	t.Run("should round-trip a playbook by name", func(t *testing.T) {
+		adapter := newTestAdapter(t)
+		pb := Playbook{Name: "nightly", ContinueOnError: true, Steps: []PlaybookStep{{Command: "project:analyze", Args: []string{"--deep"}}}}
+
+		path, err := adapter.SavePlaybook(pb)
+		require.NoError(t, err)
+		assert.FileExists(t, path)
+
+		loaded, err := adapter.LoadPlaybook("nightly")
+		require.NoError(t, err)
+		assert.Equal(t, pb, loaded)
+	})
+
+	t.Run("should reject names outside the playbooks directory", func(t *testing.T) {
+		adapter := newTestAdapter(t)
+
+		_, err := adapter.SavePlaybook(Playbook{Name: "../escape"})
+		assert.Error(t, err)
+
+		_, err = adapter.LoadPlaybook("../../settings")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package mcf
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// killProcessGroup starts cmd in its own process group and makes cancellation kill
+// the whole group, so children started by bash or the Claude CLI do not outlive it
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
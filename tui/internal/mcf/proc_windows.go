@@ -0,0 +1,8 @@
+//go:build windows
+
+package mcf
+
+import "os/exec"
+
+// killProcessGroup keeps exec's default cancellation, which kills the process
+func killProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,162 @@
+package mcf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RequiredDirs are the .claude directories the adapter reads operations from
+var RequiredDirs = []string{
+	".claude",
+	filepath.Join(".claude", "agents"),
+	filepath.Join(".claude", "commands"),
+}
+
+// InstallationStatus describes whether an MCF root has the directories the TUI needs
+type InstallationStatus struct {
+	MCFRoot     string
+	MissingDirs []string // required directories that do not exist
+	EmptyDirs   []string // operation directories that exist but hold no .md files
+}
+
+// CheckInstallation inspects mcfRoot for the required .claude layout
+func CheckInstallation(mcfRoot string) InstallationStatus {
+	status := InstallationStatus{MCFRoot: mcfRoot}
+
+	for _, dir := range RequiredDirs {
+		info, err := os.Stat(filepath.Join(mcfRoot, dir))
+		if err != nil || !info.IsDir() {
+			status.MissingDirs = append(status.MissingDirs, dir)
+			continue
+		}
+
+		if dir != ".claude" && !containsMarkdown(filepath.Join(mcfRoot, dir)) {
+			status.EmptyDirs = append(status.EmptyDirs, dir)
+		}
+	}
+
+	return status
+}
+
+// Installed reports whether every required directory exists
+func (s InstallationStatus) Installed() bool {
+	return len(s.MissingDirs) == 0
+}
+
+// IsMissing reports whether a specific required directory is absent, either
+// itself or because the whole .claude root is missing. Other missing
+// directories don't count, so agents stay usable when only commands/ is gone.
+func (s InstallationStatus) IsMissing(dir string) bool {
+	for _, missing := range s.MissingDirs {
+		if missing == dir || missing == ".claude" {
+			return true
+		}
+	}
+	return false
+}
+
+// RootMissing reports whether the .claude directory itself is absent
+func (s InstallationStatus) RootMissing() bool {
+	return s.IsMissing(".claude")
+}
+
+// IsEmpty reports whether a specific operation directory has no operations
+func (s InstallationStatus) IsEmpty(dir string) bool {
+	for _, empty := range s.EmptyDirs {
+		if empty == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// Guidance returns an actionable message for the given operation directory, or "" if it is usable
+func (s InstallationStatus) Guidance(dir string) string {
+	switch {
+	case s.RootMissing():
+		return fmt.Sprintf("MCF is not installed in %s (missing .claude).\nRun `mcf-tui init` in your project or the MCF installer to create the .claude structure.",
+			s.MCFRoot)
+	case s.IsMissing(dir):
+		return fmt.Sprintf("%s are not installed in %s (missing %s).\nRun `mcf-tui init --force` to recreate it; existing operations are kept.",
+			operationKind(dir), s.MCFRoot, dir)
+	case s.IsEmpty(dir):
+		return fmt.Sprintf("No operations found in %s.\nAdd .md files there or re-run the MCF installer.",
+			filepath.Join(s.MCFRoot, dir))
+	}
+	return ""
+}
+
+// operationKind names what an operation directory holds, for messages
+func operationKind(dir string) string {
+	name := filepath.Base(dir)
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// defaultProjectSettings is the minimal settings.json written by InitProject
+const defaultProjectSettings = `{
+  "version": "1.0.0",
+  "env": {
+    "ANTHROPIC_MODEL": "claude-sonnet-4"
+  },
+  "outputStyle": "default",
+  "hooks": {}
+}
+`
+
+// ErrAlreadyInitialized is returned by InitProject when .claude exists and force is not set
+var ErrAlreadyInitialized = errors.New(".claude already exists")
+
+// InitProject scaffolds the .claude structure and a minimal settings.json in dir.
+// An existing .claude is left alone unless force is set, in which case missing
+// directories are created and settings.json is rewritten; agents and commands are kept.
+func InitProject(dir string, force bool) error {
+	if info, err := os.Stat(filepath.Join(dir, ".claude")); err == nil && info.IsDir() && !force {
+		return fmt.Errorf("%w in %s (use --force to re-initialize)", ErrAlreadyInitialized, dir)
+	}
+
+	if err := createRequiredDirs(dir); err != nil {
+		return err
+	}
+	return writeDefaultSettings(dir, true)
+}
+
+// writeDefaultSettings writes the minimal settings.json, keeping an existing file unless overwrite is set
+func writeDefaultSettings(dir string, overwrite bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	}
+	f, err := os.OpenFile(SettingsPath(dir), flags, 0644)
+	if !overwrite && os.IsExist(err) {
+		return nil
+	}
+	if err == nil {
+		_, err = f.WriteString(defaultProjectSettings)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write settings.json: %w", err)
+	}
+	return nil
+}
+
+// containsMarkdown reports whether dir contains at least one .md file at any depth
+func containsMarkdown(dir string) bool {
+	found := false
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || found {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".md") {
+			found = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return found
+}
@@ -0,0 +1,134 @@
+package mcf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFile creates a file and its parent directories under root
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, rel)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestCheckInstallation(t *testing.T) {
+	t.Run("should report missing .claude directory", func(t *testing.T) {
+		root := t.TempDir()
+
+		status := CheckInstallation(root)
+
+		assert.False(t, status.Installed())
+		assert.Contains(t, status.MissingDirs, ".claude")
+		assert.Contains(t, status.Guidance(filepath.Join(".claude", "commands")), "not installed")
+	})
+
+	t.Run("should distinguish empty from missing directories", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(root, ".claude", "agents"), 0755))
+		writeFile(t, root, ".claude/commands/project/analyze.md", "# Analyze")
+
+		status := CheckInstallation(root)
+
+		assert.True(t, status.Installed())
+		assert.True(t, status.IsEmpty(filepath.Join(".claude", "agents")))
+		assert.Contains(t, status.Guidance(filepath.Join(".claude", "agents")), "No operations found")
+		assert.Empty(t, status.Guidance(filepath.Join(".claude", "commands")))
+	})
+
+	t.Run("should report missing commands directory only", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, ".claude/agents/orchestrator.md", "# Orchestrator")
+
+		status := CheckInstallation(root)
+
+		assert.False(t, status.Installed())
+		assert.Equal(t, []string{filepath.Join(".claude", "commands")}, status.MissingDirs)
+		assert.Empty(t, status.Guidance(filepath.Join(".claude", "agents")))
+		assert.False(t, status.RootMissing())
+		guidance := status.Guidance(filepath.Join(".claude", "commands"))
+		assert.Contains(t, guidance, "Commands are not installed")
+		assert.NotContains(t, guidance, "agents")
+	})
+}
+
+func TestCheckHealth(t *testing.T) {
+	adapter := newTestAdapter(t)
+	assert.NoError(t, adapter.CheckHealth())
+
+	require.NoError(t, os.RemoveAll(filepath.Join(adapter.mcfRoot, ".claude", "agents")))
+	assert.ErrorContains(t, adapter.CheckHealth(), "agents")
+}
+
+func TestInitProject(t *testing.T) {
+	t.Run("should scaffold a loadable .claude structure", func(t *testing.T) {
+		root := t.TempDir()
+		t.Setenv("MCF_TUI_LOG_DIR", t.TempDir())
+
+		require.NoError(t, InitProject(root, false))
+
+		assert.True(t, CheckInstallation(root).Installed())
+		settings, err := LoadRawSettings(root)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", settings["version"])
+		assert.Empty(t, CheckSettings(settings))
+
+		_, err = NewMCFAdapter(root)
+		assert.NoError(t, err, "Scaffolded project should load")
+	})
+
+	t.Run("should refuse to clobber an existing .claude without force", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, ".claude/settings.json", `{"version": "9.9.9"}`)
+
+		err := InitProject(root, false)
+
+		assert.ErrorIs(t, err, ErrAlreadyInitialized)
+		settings, loadErr := LoadRawSettings(root)
+		require.NoError(t, loadErr)
+		assert.Equal(t, "9.9.9", settings["version"], "Existing settings should be untouched")
+	})
+
+	t.Run("should keep user operations when forced", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, ".claude/agents/custom.md", "# Custom")
+
+		require.NoError(t, InitProject(root, true))
+
+		assert.FileExists(t, filepath.Join(root, ".claude", "agents", "custom.md"))
+		assert.FileExists(t, SettingsPath(root))
+	})
+}
+
+func TestNewMCFAdapter_PartialInstall(t *testing.T) {
+	t.Run("should load agents when only commands/ is missing", func(t *testing.T) {
+		t.Setenv("MCF_TUI_LOG_DIR", t.TempDir())
+		root := t.TempDir()
+		writeFile(t, root, ".claude/settings.json", `{"version": "1.0.0"}`)
+		writeFile(t, root, ".claude/agents/orchestrator.md", "# Orchestrator")
+
+		adapter, err := NewMCFAdapter(root)
+
+		require.NoError(t, err)
+		require.Len(t, adapter.GetAgents(), 1)
+		assert.Equal(t, "orchestrator", adapter.GetAgents()[0].Name)
+		assert.Empty(t, adapter.GetCommands())
+	})
+}
+
+func TestNewMCFAdapter_NotInstalled(t *testing.T) {
+	t.Run("should fail with a clear message when .claude is missing", func(t *testing.T) {
+		t.Setenv("MCF_TUI_LOG_DIR", t.TempDir())
+
+		adapter, err := NewMCFAdapter(t.TempDir())
+
+		assert.Nil(t, adapter)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "MCF not installed")
+	})
+}
@@ -0,0 +1,38 @@
+package mcf
+
+import "time"
+
+// ExecutionReport is the machine-readable record of one command run, for CI use.
+// Output fields are already capped: anything past the in-memory limit ends with a
+// truncation marker naming the file that holds the full output.
+type ExecutionReport struct {
+	Command    string    `json:"command"`
+	Args       []string  `json:"args"`
+	Success    bool      `json:"success"`
+	ExitCode   int       `json:"exitCode"`
+	Output     string    `json:"output"`
+	Stdout     string    `json:"stdout"`
+	Stderr     string    `json:"stderr"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"durationMs"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// NewExecutionReport builds a report for a finished command
+func NewExecutionReport(command string, args []string, result *CommandResult, started time.Time, duration time.Duration) ExecutionReport {
+	if args == nil {
+		args = []string{}
+	}
+	return ExecutionReport{
+		Command:    command,
+		Args:       args,
+		Success:    result.Success,
+		ExitCode:   result.Code,
+		Output:     result.Output,
+		Stdout:     result.Stdout,
+		Stderr:     result.Stderr,
+		Error:      result.Error,
+		DurationMs: duration.Milliseconds(),
+		Timestamp:  started.UTC(),
+	}
+}
@@ -0,0 +1,110 @@
+package mcf
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FindMCFRoot walks up from start looking for a directory that contains .claude
+func FindMCFRoot(start string) (string, error) {
+	current := start
+	for {
+		if info, err := os.Stat(filepath.Join(current, ".claude")); err == nil && info.IsDir() {
+			return current, nil
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", fmt.Errorf("no .claude directory found above %s", start)
+		}
+		current = parent
+	}
+}
+
+// SettingsPath returns the location of settings.json for an MCF root
+func SettingsPath(mcfRoot string) string {
+	return filepath.Join(mcfRoot, ".claude", "settings.json")
+}
+
+// LoadRawSettings reads settings.json as a generic map so no keys are dropped
+func LoadRawSettings(mcfRoot string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(SettingsPath(mcfRoot))
+	if err != nil {
+		return nil, err
+	}
+
+	settings := map[string]interface{}{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("invalid settings.json: %w", err)
+	}
+	return settings, nil
+}
+
+// FormatSettingsYAML renders settings as YAML for read-only display
+func FormatSettingsYAML(settings map[string]interface{}) (string, error) {
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FormatSettingsJSON renders settings as indented JSON
+func FormatSettingsJSON(settings map[string]interface{}) (string, error) {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// SettingMatch is a settings key found by SearchSettings
+type SettingMatch struct {
+	Key   string      `json:"key"` // dot-notation path, e.g. "env.ANTHROPIC_MODEL"
+	Value interface{} `json:"value"`
+}
+
+// SearchSettings returns leaf settings whose dot-notation key or value contains query,
+// case-insensitively, sorted by key
+func SearchSettings(settings map[string]interface{}, query string) []SettingMatch {
+	query = strings.ToLower(query)
+	matches := []SettingMatch{}
+
+	var walk func(prefix string, value interface{})
+	walk = func(prefix string, value interface{}) {
+		if nested, ok := value.(map[string]interface{}); ok && (len(nested) > 0 || prefix == "") {
+			for k, v := range nested {
+				walk(prefix+"."+k, v)
+			}
+			return
+		}
+
+		key := strings.TrimPrefix(prefix, ".")
+		if strings.Contains(strings.ToLower(key), query) ||
+			strings.Contains(strings.ToLower(FormatSettingValue(value)), query) {
+			matches = append(matches, SettingMatch{Key: key, Value: value})
+		}
+	}
+	walk("", settings)
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Key < matches[j].Key })
+	return matches
+}
+
+// FormatSettingValue renders a settings value on one line: strings bare, everything else as JSON
+func FormatSettingValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,170 @@
+package mcf
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// RequiredSetting is a settings.json key the TUI and Claude CLI rely on
+type RequiredSetting struct {
+	Key     string // dot-notation path
+	Default interface{}
+	Valid   func(value interface{}) bool
+}
+
+// RequiredSettings lists the keys CheckSettings verifies and RepairSettings fills in
+var RequiredSettings = []RequiredSetting{
+	{Key: "env.ANTHROPIC_MODEL", Default: "claude-sonnet-4", Valid: isNonEmptyString},
+	{Key: "outputStyle", Default: "default", Valid: isNonEmptyString},
+	{Key: "hooks", Default: map[string]interface{}{}, Valid: isObject},
+}
+
+// SettingsIssue describes a required key that is missing or has an unusable value
+type SettingsIssue struct {
+	Key     string
+	Problem string // "missing" or "invalid"
+}
+
+func (i SettingsIssue) String() string {
+	return fmt.Sprintf("%s is %s", i.Key, i.Problem)
+}
+
+// CheckSettings reports required keys that are missing or invalid
+func CheckSettings(settings map[string]interface{}) []SettingsIssue {
+	var issues []SettingsIssue
+	for _, required := range RequiredSettings {
+		value, ok := lookupSetting(settings, required.Key)
+		switch {
+		case !ok:
+			issues = append(issues, SettingsIssue{Key: required.Key, Problem: "missing"})
+		case !required.Valid(value):
+			issues = append(issues, SettingsIssue{Key: required.Key, Problem: "invalid"})
+		}
+	}
+	return issues
+}
+
+// RepairSettings fills missing or invalid required keys in settings.json with defaults,
+// keeping every other value. The original file is backed up first; the backup path and
+// the repaired issues are returned. Nothing is written when there is nothing to repair.
+func RepairSettings(mcfRoot string) (string, []SettingsIssue, error) {
+	settings, err := LoadRawSettings(mcfRoot)
+	if err != nil {
+		return "", nil, err
+	}
+
+	issues := CheckSettings(settings)
+	if len(issues) == 0 {
+		return "", nil, nil
+	}
+
+	path := SettingsPath(mcfRoot)
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	backupPath, err := writeBackup(path, original, info.Mode().Perm())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to back up settings.json: %w", err)
+	}
+
+	for _, issue := range issues {
+		for _, required := range RequiredSettings {
+			if required.Key == issue.Key {
+				if err := storeSetting(settings, required.Key, required.Default); err != nil {
+					return backupPath, nil, err
+				}
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return backupPath, nil, err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), info.Mode().Perm()); err != nil {
+		return backupPath, nil, err
+	}
+
+	return backupPath, issues, nil
+}
+
+// writeBackup saves data next to path as a timestamped .bak and returns its path.
+// The timestamp has nanosecond resolution and an existing backup is never
+// overwritten: a counter is appended until the name is free.
+func writeBackup(path string, data []byte, perm os.FileMode) (string, error) {
+	base := fmt.Sprintf("%s.%s", path, time.Now().Format("20060102-150405.000000000"))
+	backupPath := base + ".bak"
+	for n := 1; ; n++ {
+		f, err := os.OpenFile(backupPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+		if os.IsExist(err) {
+			backupPath = fmt.Sprintf("%s-%d.bak", base, n)
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err := f.Write(data); err != nil {
+			f.Close()
+			return "", err
+		}
+		return backupPath, f.Close()
+	}
+}
+
+// lookupSetting reads a dot-notation key from nested settings
+func lookupSetting(settings map[string]interface{}, key string) (interface{}, bool) {
+	parts := strings.Split(key, ".")
+	current := settings
+	for i, part := range parts {
+		value, ok := current[part]
+		if !ok {
+			return nil, false
+		}
+		if i == len(parts)-1 {
+			return value, true
+		}
+		if current, ok = value.(map[string]interface{}); !ok {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// storeSetting writes a dot-notation key, creating intermediate objects as needed
+func storeSetting(settings map[string]interface{}, key string, value interface{}) error {
+	parts := strings.Split(key, ".")
+	current := settings
+	for _, part := range parts[:len(parts)-1] {
+		next, exists := current[part]
+		if !exists {
+			next = map[string]interface{}{}
+			current[part] = next
+		}
+		nested, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cannot set %s: %s is not an object", key, part)
+		}
+		current = nested
+	}
+	current[parts[len(parts)-1]] = value
+	return nil
+}
+
+func isNonEmptyString(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && strings.TrimSpace(s) != ""
+}
+
+func isObject(value interface{}) bool {
+	_, ok := value.(map[string]interface{})
+	return ok
+}
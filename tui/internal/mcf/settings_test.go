@@ -0,0 +1,143 @@
+package mcf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchSettings(t *testing.T) {
+	settings := map[string]interface{}{
+		"version": "1.0.0",
+		"env": map[string]interface{}{
+			"ANTHROPIC_MODEL":   "claude-sonnet-4",
+			"DISABLE_TELEMETRY": "1",
+		},
+		"hooks":   map[string]interface{}{},
+		"timeout": float64(30),
+	}
+
+	t.Run("should match keys case-insensitively", func(t *testing.T) {
+		matches := SearchSettings(settings, "model")
+
+		assert.Equal(t, []SettingMatch{{Key: "env.ANTHROPIC_MODEL", Value: "claude-sonnet-4"}}, matches)
+	})
+
+	t.Run("should match values", func(t *testing.T) {
+		matches := SearchSettings(settings, "SONNET")
+
+		assert.Len(t, matches, 1)
+		assert.Equal(t, "env.ANTHROPIC_MODEL", matches[0].Key)
+	})
+
+	t.Run("should return leaves sorted by key", func(t *testing.T) {
+		matches := SearchSettings(settings, "")
+
+		var keys []string
+		for _, m := range matches {
+			keys = append(keys, m.Key)
+		}
+		assert.Equal(t, []string{"env.ANTHROPIC_MODEL", "env.DISABLE_TELEMETRY", "hooks", "timeout", "version"}, keys)
+	})
+
+	t.Run("should return an empty result when nothing matches", func(t *testing.T) {
+		assert.Empty(t, SearchSettings(settings, "nope"))
+		assert.Empty(t, SearchSettings(map[string]interface{}{}, ""))
+	})
+}
+
+func TestCheckSettings(t *testing.T) {
+	t.Run("should accept complete settings", func(t *testing.T) {
+		settings := map[string]interface{}{
+			"env":         map[string]interface{}{"ANTHROPIC_MODEL": "claude-sonnet-4"},
+			"outputStyle": "default",
+			"hooks":       map[string]interface{}{},
+		}
+
+		assert.Empty(t, CheckSettings(settings))
+	})
+
+	t.Run("should report missing and invalid keys", func(t *testing.T) {
+		settings := map[string]interface{}{
+			"outputStyle": "",
+			"hooks":       "none",
+		}
+
+		assert.Equal(t, []SettingsIssue{
+			{Key: "env.ANTHROPIC_MODEL", Problem: "missing"},
+			{Key: "outputStyle", Problem: "invalid"},
+			{Key: "hooks", Problem: "invalid"},
+		}, CheckSettings(settings))
+	})
+}
+
+func TestRepairSettings(t *testing.T) {
+	t.Run("should fill defaults and keep custom fields", func(t *testing.T) {
+		root := t.TempDir()
+		original := `{"version": "2.0.0", "env": {"CUSTOM": "keep"}, "outputStyle": "concise", "statusLine": {"type": "command"}}`
+		writeFile(t, root, ".claude/settings.json", original)
+
+		backupPath, repaired, err := RepairSettings(root)
+		require.NoError(t, err)
+		assert.Equal(t, []SettingsIssue{
+			{Key: "env.ANTHROPIC_MODEL", Problem: "missing"},
+			{Key: "hooks", Problem: "missing"},
+		}, repaired)
+
+		settings, err := LoadRawSettings(root)
+		require.NoError(t, err)
+		assert.Empty(t, CheckSettings(settings))
+		assert.Equal(t, "2.0.0", settings["version"])
+		assert.Equal(t, "concise", settings["outputStyle"])
+		assert.Equal(t, map[string]interface{}{"type": "command"}, settings["statusLine"])
+		assert.Equal(t, map[string]interface{}{"CUSTOM": "keep", "ANTHROPIC_MODEL": "claude-sonnet-4"}, settings["env"])
+
+		backup, err := os.ReadFile(backupPath)
+		require.NoError(t, err)
+		assert.Equal(t, original, string(backup))
+		assert.Equal(t, filepath.Dir(SettingsPath(root)), filepath.Dir(backupPath))
+	})
+
+	t.Run("should keep every backup when repairing twice in a second", func(t *testing.T) {
+		root := t.TempDir()
+		var backups []string
+		for _, version := range []string{"1", "2"} {
+			writeFile(t, root, ".claude/settings.json", `{"version": "`+version+`"}`)
+			backupPath, _, err := RepairSettings(root)
+			require.NoError(t, err)
+			backups = append(backups, backupPath)
+		}
+
+		require.NotEqual(t, backups[0], backups[1])
+		for i, version := range []string{"1", "2"} {
+			backup, err := os.ReadFile(backups[i])
+			require.NoError(t, err)
+			assert.Equal(t, `{"version": "`+version+`"}`, string(backup))
+		}
+	})
+
+	t.Run("should not touch complete settings", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, ".claude/settings.json", `{"env": {"ANTHROPIC_MODEL": "m"}, "outputStyle": "default", "hooks": {}}`)
+
+		backupPath, repaired, err := RepairSettings(root)
+		require.NoError(t, err)
+		assert.Empty(t, backupPath)
+		assert.Empty(t, repaired)
+
+		entries, err := os.ReadDir(filepath.Join(root, ".claude"))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("should report issues found at adapter startup", func(t *testing.T) {
+		adapter := newTestAdapterWith(t, map[string]string{
+			".claude/settings.json": `{"outputStyle": "default", "hooks": {}}`,
+		})
+
+		assert.Equal(t, []SettingsIssue{{Key: "env.ANTHROPIC_MODEL", Problem: "missing"}}, adapter.SettingsIssues())
+	})
+}
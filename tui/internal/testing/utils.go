@@ -3,8 +3,12 @@ package testing
 import (
 	"bytes"
 	"context"
+	"flag"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 	"testing"
@@ -362,3 +366,37 @@ func (b *PerformanceBenchmark) Run(t *testing.T) {
 	t.Logf("Benchmark %s completed: %d iterations in %v (avg: %v per iteration)",
 		b.name, b.iterations, duration, avgDuration)
 }
+
+// Golden file utilities for view rendering tests
+var updateGolden = flag.Bool("update", false, "Rewrite golden files with the current rendered output")
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+
+// StripANSI removes terminal escape sequences so rendered output compares stably
+func StripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// GoldenPath returns the location of the golden file for name within the package's testdata
+func GoldenPath(name string) string {
+	return filepath.Join("testdata", name+".golden")
+}
+
+// AssertGolden compares rendered view output against testdata/<name>.golden.
+// Run the tests with -update to regenerate the expected output.
+func AssertGolden(t *testing.T, name string, actual string) {
+	t.Helper()
+
+	actual = StripANSI(actual)
+	path := GoldenPath(name)
+
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(actual), 0644))
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "Golden file missing, run with -update to create it")
+	assert.Equal(t, string(expected), actual, "Rendered output differs from %s", path)
+}
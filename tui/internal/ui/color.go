@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// plainIcons switches icons to their ASCII fallbacks
+var plainIcons bool
+
+// icons maps icon names to their Unicode glyph and ASCII fallback
+var icons = map[string][2]string{
+	"status":  {"●", "*"},
+	"cursor":  {"►", ">"},
+	"marker":  {"▶", ">"},
+	"ok":      {"✅", "+"},
+	"check":   {"✓", "+"},
+	"cross":   {"✗", "x"},
+	"warning": {"⚠", "!"},
+	"command": {"⚡", ">"},
+	"agent":   {"🤖", "@"},
+	"error":   {"❌", "x"},
+	"info":    {"ℹ", "i"},
+	"pin":     {"📍", "@"},
+	"filled":  {"█", "#"},
+	"empty":   {"░", "-"},
+}
+
+// Icon returns the named icon, or its ASCII fallback in no-color mode
+func Icon(name string) string {
+	glyphs, ok := icons[name]
+	if !ok {
+		return ""
+	}
+	if plainIcons {
+		return glyphs[1]
+	}
+	return glyphs[0]
+}
+
+// NoColorRequested reports whether output should be monochrome: the --no-color
+// flag, NO_COLOR, or a terminal (or pipe) that lipgloss detects as colorless
+func NoColorRequested(flag bool) bool {
+	return flag || os.Getenv("NO_COLOR") != "" || lipgloss.ColorProfile() == termenv.Ascii
+}
+
+// SetNoColor switches rendering to a plain profile with no escape sequences and ASCII icons
+func SetNoColor(enabled bool) {
+	plainIcons = enabled
+	if enabled {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetNoColor(t *testing.T) {
+	previous := lipgloss.ColorProfile()
+	t.Cleanup(func() {
+		lipgloss.SetColorProfile(previous)
+		plainIcons = false
+	})
+
+	render := func() string {
+		return newGoldenDashboard().Render(goldenWidth, goldenHeight) +
+			newGoldenLogViewer().Render(goldenWidth) +
+			RenderStatusIndicator("connected", NewTheme())
+	}
+
+	t.Run("should emit escape sequences on a color terminal", func(t *testing.T) {
+		lipgloss.SetColorProfile(termenv.TrueColor)
+
+		assert.Contains(t, render(), "\x1b[")
+		assert.Equal(t, "●", Icon("status"))
+	})
+
+	t.Run("should render plain text with ASCII icons in no-color mode", func(t *testing.T) {
+		lipgloss.SetColorProfile(termenv.TrueColor)
+		SetNoColor(true)
+
+		output := render()
+		assert.NotContains(t, output, "\x1b[")
+		assert.Contains(t, output, "* connected")
+		for _, glyph := range []string{"●", "►", "⚡", "❌", "📍"} {
+			assert.False(t, strings.Contains(output, glyph), "Unexpected %s in no-color output", glyph)
+		}
+	})
+
+	t.Run("should honor NO_COLOR", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+
+		assert.True(t, NoColorRequested(false))
+	})
+}
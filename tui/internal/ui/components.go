@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,11 +14,14 @@ import (
 // Interactive List Component
 type InteractiveList struct {
 	theme    *Theme
-	items    []ListItem
+	items    []ListItem // items shown under the current category filter
+	allItems []ListItem
+	category string // Metadata["category"] to show, "" for all
 	selected int
 	focused  bool
 	title    string
 	height   int
+	marked   []string // titles of marked items, in the order they were marked
 }
 
 type ListItem struct {
@@ -39,15 +43,78 @@ func NewInteractiveList(theme *Theme, title string, height int) *InteractiveList
 }
 
 func (l *InteractiveList) SetItems(items []ListItem) {
-	l.items = items
-	if l.selected >= len(items) {
-		l.selected = len(items) - 1
+	l.allItems = items
+	l.applyFilter()
+}
+
+// applyFilter narrows allItems to the current category and clamps the selection
+func (l *InteractiveList) applyFilter() {
+	l.items = l.allItems
+	if l.category != "" {
+		l.items = []ListItem{}
+		for _, item := range l.allItems {
+			if itemCategory(item) == l.category {
+				l.items = append(l.items, item)
+			}
+		}
+	}
+
+	if l.selected >= len(l.items) {
+		l.selected = len(l.items) - 1
 	}
 	if l.selected < 0 {
 		l.selected = 0
 	}
 }
 
+// itemCategory returns the category stored in an item's metadata, or ""
+func itemCategory(item ListItem) string {
+	category, _ := item.Metadata["category"].(string)
+	return category
+}
+
+// SetCategoryFilter shows only items whose Metadata["category"] matches; "" shows all
+func (l *InteractiveList) SetCategoryFilter(category string) {
+	l.category = category
+	l.selected = 0
+	l.applyFilter()
+}
+
+// CategoryFilter returns the active category filter, "" when showing all items
+func (l *InteractiveList) CategoryFilter() string {
+	return l.category
+}
+
+// Categories returns the distinct item categories in sorted order
+func (l *InteractiveList) Categories() []string {
+	seen := map[string]bool{}
+	var categories []string
+	for _, item := range l.allItems {
+		if category := itemCategory(item); category != "" && !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// NextCategoryFilter cycles the filter through all items and then each category
+func (l *InteractiveList) NextCategoryFilter() {
+	categories := l.Categories()
+	next := ""
+	if l.category == "" && len(categories) > 0 {
+		next = categories[0]
+	} else {
+		for i, category := range categories {
+			if category == l.category && i+1 < len(categories) {
+				next = categories[i+1]
+			}
+		}
+	}
+	l.SetCategoryFilter(next)
+}
+
 func (l *InteractiveList) SetFocus(focused bool) {
 	l.focused = focused
 }
@@ -63,6 +130,42 @@ func (l *InteractiveList) GetSelectedItem() *ListItem {
 	return nil
 }
 
+// ToggleMark marks or unmarks the selected item for a multi-item action
+func (l *InteractiveList) ToggleMark() {
+	item := l.GetSelectedItem()
+	if item == nil {
+		return
+	}
+
+	for i, title := range l.marked {
+		if title == item.Title {
+			l.marked = append(l.marked[:i], l.marked[i+1:]...)
+			return
+		}
+	}
+	l.marked = append(l.marked, item.Title)
+}
+
+// IsMarked reports whether the item with the given title is marked
+func (l *InteractiveList) IsMarked(title string) bool {
+	for _, marked := range l.marked {
+		if marked == title {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkedTitles returns marked item titles in the order they were marked
+func (l *InteractiveList) MarkedTitles() []string {
+	return append([]string(nil), l.marked...)
+}
+
+// ClearMarks unmarks all items
+func (l *InteractiveList) ClearMarks() {
+	l.marked = nil
+}
+
 func (l *InteractiveList) Update(msg tea.Msg) (*InteractiveList, tea.Cmd) {
 	if !l.focused {
 		return l, nil
@@ -94,7 +197,7 @@ func (l *InteractiveList) Update(msg tea.Msg) (*InteractiveList, tea.Cmd) {
 func (l *InteractiveList) Render(width int) string {
 	if len(l.items) == 0 {
 		empty := l.theme.Muted.Render("No items available")
-		return RenderBox(empty, l.title, width, l.height, l.theme)
+		return RenderBox(empty, l.displayTitle(), width, l.height, l.theme)
 	}
 
 	content := ""
@@ -116,11 +219,20 @@ func (l *InteractiveList) Render(width int) string {
 
 		if idx == l.selected && l.focused {
 			style = l.theme.ListItemActive
-			cursor = "► "
+			cursor = Icon("cursor") + " "
 		} else {
 			style = l.theme.ListItem
 		}
 
+		// Mark column only appears once something is marked
+		if len(l.marked) > 0 {
+			if l.IsMarked(item.Title) {
+				cursor += Icon("check") + " "
+			} else {
+				cursor += "  "
+			}
+		}
+
 		// Item line with cursor, title, and status
 		line := cursor + item.Title
 		if item.Status != "" {
@@ -149,7 +261,15 @@ func (l *InteractiveList) Render(width int) string {
 		content += "\n" + l.theme.Muted.Render(scrollInfo)
 	}
 
-	return RenderBox(content, l.title, width, l.height, l.theme)
+	return RenderBox(content, l.displayTitle(), width, l.height, l.theme)
+}
+
+// displayTitle appends the active category filter to the list title
+func (l *InteractiveList) displayTitle() string {
+	if l.category == "" {
+		return l.title
+	}
+	return fmt.Sprintf("%s [%s: %d/%d]", l.title, l.category, len(l.items), len(l.allItems))
 }
 
 // Command Input Component
@@ -358,6 +478,9 @@ type LogViewer struct {
 	width       int
 	searchMode  bool
 	searchInput textinput.Model
+
+	hideNonMatches bool // filter out entries that don't match the search
+	currentMatch   int  // index into displayed entries of the focused match, -1 if none
 }
 
 type LogEntry struct {
@@ -380,6 +503,9 @@ func NewLogViewer(theme *Theme, height int) *LogViewer {
 		height:      height,
 		searchMode:  false,
 		searchInput: searchInput,
+
+		hideNonMatches: true,
+		currentMatch:   -1,
 	}
 }
 
@@ -392,6 +518,7 @@ func (lv *LogViewer) AddLog(entry LogEntry) {
 	// Limit log history
 	if len(lv.logs) > 1000 {
 		lv.logs = lv.logs[100:] // Keep last 900 entries
+		lv.currentMatch = -1
 	}
 }
 
@@ -409,12 +536,14 @@ func (lv *LogViewer) SetSearchMode(enabled bool) {
 	} else {
 		lv.searchInput.Blur()
 		lv.filter = ""
+		lv.currentMatch = -1
 	}
 }
 
 func (lv *LogViewer) Clear() {
 	lv.logs = []LogEntry{}
 	lv.scrollPos = 0
+	lv.currentMatch = -1
 }
 
 func (lv *LogViewer) scrollToBottom() {
@@ -436,10 +565,13 @@ func (lv *LogViewer) Update(msg tea.Msg) (*LogViewer, tea.Cmd) {
 				lv.filter = lv.searchInput.Value()
 				lv.searchMode = false
 				lv.searchInput.Blur()
+				lv.currentMatch = -1
+				lv.NextMatch()
 			case "esc":
 				lv.searchMode = false
 				lv.searchInput.Blur()
 				lv.filter = ""
+				lv.currentMatch = -1
 			default:
 				lv.searchInput, cmd = lv.searchInput.Update(msg)
 			}
@@ -477,12 +609,108 @@ func (lv *LogViewer) Update(msg tea.Msg) (*LogViewer, tea.Cmd) {
 			lv.searchInput.Focus()
 		case "c":
 			lv.Clear()
+		case "n":
+			lv.NextMatch()
+		case "N":
+			lv.PrevMatch()
+		case "h":
+			lv.SetHideNonMatches(!lv.hideNonMatches)
 		}
 	}
 
 	return lv, nil
 }
 
+// matches reports whether an entry contains the current search query
+func (lv *LogViewer) matches(entry LogEntry) bool {
+	if lv.filter == "" {
+		return false
+	}
+	query := strings.ToLower(lv.filter)
+	return strings.Contains(strings.ToLower(entry.Message), query) ||
+		strings.Contains(strings.ToLower(entry.Component), query)
+}
+
+// displayedLogs returns the entries shown under the current search settings
+func (lv *LogViewer) displayedLogs() []LogEntry {
+	if lv.filter == "" || !lv.hideNonMatches {
+		return lv.logs
+	}
+
+	filtered := []LogEntry{}
+	for _, entry := range lv.logs {
+		if lv.matches(entry) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// matchIndexes returns the positions of matching entries within displayedLogs
+func (lv *LogViewer) matchIndexes() []int {
+	var indexes []int
+	for i, entry := range lv.displayedLogs() {
+		if lv.matches(entry) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// NextMatch focuses the next matching entry, wrapping to the first
+func (lv *LogViewer) NextMatch() {
+	indexes := lv.matchIndexes()
+	if len(indexes) == 0 {
+		return
+	}
+
+	target := indexes[0]
+	for _, idx := range indexes {
+		if idx > lv.currentMatch {
+			target = idx
+			break
+		}
+	}
+	lv.focusMatch(target)
+}
+
+// PrevMatch focuses the previous matching entry, wrapping to the last
+func (lv *LogViewer) PrevMatch() {
+	indexes := lv.matchIndexes()
+	if len(indexes) == 0 {
+		return
+	}
+
+	target := indexes[len(indexes)-1]
+	for i := len(indexes) - 1; i >= 0; i-- {
+		if indexes[i] < lv.currentMatch {
+			target = indexes[i]
+			break
+		}
+	}
+	lv.focusMatch(target)
+}
+
+// focusMatch marks idx as the current match and scrolls it into view
+func (lv *LogViewer) focusMatch(idx int) {
+	lv.currentMatch = idx
+	lv.following = false
+
+	visibleLines := lv.height - 4
+	if idx < lv.scrollPos {
+		lv.scrollPos = idx
+	} else if visibleLines > 0 && idx >= lv.scrollPos+visibleLines {
+		lv.scrollPos = idx - visibleLines + 1
+	}
+}
+
+// SetHideNonMatches switches between filtering and highlight-only search
+func (lv *LogViewer) SetHideNonMatches(hide bool) {
+	lv.hideNonMatches = hide
+	lv.currentMatch = -1
+	lv.scrollPos = 0
+}
+
 func (lv *LogViewer) Render(width int) string {
 	lv.width = width
 
@@ -492,17 +720,7 @@ func (lv *LogViewer) Render(width int) string {
 
 	content := ""
 
-	// Filter logs
-	filteredLogs := lv.logs
-	if lv.filter != "" {
-		filteredLogs = []LogEntry{}
-		for _, entry := range lv.logs {
-			if strings.Contains(strings.ToLower(entry.Message), strings.ToLower(lv.filter)) ||
-				strings.Contains(strings.ToLower(entry.Component), strings.ToLower(lv.filter)) {
-				filteredLogs = append(filteredLogs, entry)
-			}
-		}
-	}
+	filteredLogs := lv.displayedLogs()
 
 	// Render visible logs
 	visibleLines := lv.height - 4
@@ -511,19 +729,37 @@ func (lv *LogViewer) Render(width int) string {
 
 	for i := startIdx; i < endIdx; i++ {
 		entry := filteredLogs[i]
-		content += lv.renderLogEntry(entry) + "\n"
+		line := lv.renderLogEntry(entry)
+		if i == lv.currentMatch {
+			line = lv.theme.Warning.Render(Icon("marker")+" ") + line
+		}
+		content += line + "\n"
 	}
 
 	// Status line
 	statusLine := ""
 	if lv.following {
-		statusLine += lv.theme.Success.Render("● FOLLOWING")
+		statusLine += lv.theme.Success.Render(Icon("status") + " FOLLOWING")
 	} else {
-		statusLine += lv.theme.Muted.Render("● PAUSED")
+		statusLine += lv.theme.Muted.Render(Icon("status") + " PAUSED")
 	}
 
 	if lv.filter != "" {
-		statusLine += " " + lv.theme.Info.Render(fmt.Sprintf("Filter: '%s'", lv.filter))
+		label := "Filter"
+		if !lv.hideNonMatches {
+			label = "Search"
+		}
+		statusLine += " " + lv.theme.Info.Render(fmt.Sprintf("%s: '%s'", label, lv.filter))
+
+		if lv.currentMatch >= 0 {
+			indexes := lv.matchIndexes()
+			for n, idx := range indexes {
+				if idx == lv.currentMatch {
+					statusLine += " " + lv.theme.Muted.Render(fmt.Sprintf("match %d/%d", n+1, len(indexes)))
+					break
+				}
+			}
+		}
 	}
 
 	statusLine += " " + lv.theme.Muted.Render(fmt.Sprintf("(%d/%d)",
@@ -560,7 +796,7 @@ func (lv *LogViewer) renderLogEntry(entry LogEntry) string {
 		lv.theme.Muted.Render(timestamp),
 		levelStyle.Render(fmt.Sprintf("%-5s", entry.Level)),
 		lv.theme.Info.Render(entry.Component),
-		entry.Message,
+		lv.highlightMatches(entry.Message),
 	)
 
 	if len(line) > lv.width-4 {
@@ -569,3 +805,31 @@ func (lv *LogViewer) renderLogEntry(entry LogEntry) string {
 
 	return line
 }
+
+// highlightMatches wraps every case-insensitive occurrence of the search query in text
+func (lv *LogViewer) highlightMatches(text string) string {
+	if lv.filter == "" {
+		return text
+	}
+
+	lower := strings.ToLower(text)
+	query := strings.ToLower(lv.filter)
+	if len(lower) != len(text) {
+		// Case folding changed byte offsets; skip highlighting rather than mis-slice
+		return text
+	}
+
+	var b strings.Builder
+	for {
+		idx := strings.Index(lower, query)
+		if idx < 0 {
+			b.WriteString(text)
+			break
+		}
+		b.WriteString(text[:idx])
+		b.WriteString(lv.theme.Highlight.Render(text[idx : idx+len(query)]))
+		text = text[idx+len(query):]
+		lower = lower[idx+len(query):]
+	}
+	return b.String()
+}
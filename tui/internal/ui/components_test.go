@@ -531,6 +531,84 @@ func TestCommandInput_Render(t *testing.T) {
 	})
 }
 
+func TestInteractiveList_Marks(t *testing.T) {
+	t.Run("should keep marks in the order they were added", func(t *testing.T) {
+		list := NewInteractiveList(NewTheme(), "Commands", 10)
+		list.SetItems([]ListItem{{Title: "build"}, {Title: "test"}, {Title: "deploy"}})
+		list.SetFocus(true)
+
+		list.selected = 2
+		list.ToggleMark()
+		list.selected = 0
+		list.ToggleMark()
+
+		assert.Equal(t, []string{"deploy", "build"}, list.MarkedTitles())
+		assert.Contains(t, list.Render(60), "✓ build")
+	})
+
+	t.Run("should unmark on second toggle and clear all", func(t *testing.T) {
+		list := NewInteractiveList(NewTheme(), "Commands", 10)
+		list.SetItems([]ListItem{{Title: "build"}, {Title: "test"}})
+
+		list.ToggleMark()
+		list.ToggleMark()
+		assert.Empty(t, list.MarkedTitles())
+
+		list.ToggleMark()
+		list.ClearMarks()
+		assert.Empty(t, list.MarkedTitles())
+		assert.NotContains(t, list.Render(60), "✓")
+	})
+}
+
+func TestInteractiveList_CategoryFilter(t *testing.T) {
+	newList := func() *InteractiveList {
+		list := NewInteractiveList(NewTheme(), "Commands", 10)
+		list.SetItems([]ListItem{
+			{Title: "project:analyze", Metadata: map[string]interface{}{"category": "project"}},
+			{Title: "gh:push", Metadata: map[string]interface{}{"category": "gh"}},
+			{Title: "project:deploy", Metadata: map[string]interface{}{"category": "project"}},
+			{Title: "untyped"},
+		})
+		return list
+	}
+
+	t.Run("should narrow items to the selected category", func(t *testing.T) {
+		list := newList()
+
+		list.SetCategoryFilter("project")
+
+		require.Len(t, list.items, 2)
+		assert.Equal(t, "project:analyze", list.items[0].Title)
+		assert.Equal(t, "project:deploy", list.items[1].Title)
+		assert.Contains(t, list.Render(60), "[project: 2/4]")
+	})
+
+	t.Run("should cycle through categories and back to all", func(t *testing.T) {
+		list := newList()
+
+		var seen []string
+		for i := 0; i < 3; i++ {
+			list.NextCategoryFilter()
+			seen = append(seen, list.CategoryFilter())
+		}
+
+		assert.Equal(t, []string{"gh", "project", ""}, seen)
+		assert.Len(t, list.items, 4, "Clearing the filter should show every item")
+	})
+
+	t.Run("should keep the filter when items are replaced", func(t *testing.T) {
+		list := newList()
+		list.SetCategoryFilter("gh")
+		list.selected = 0
+
+		list.SetItems([]ListItem{{Title: "docs:build", Metadata: map[string]interface{}{"category": "docs"}}})
+
+		assert.Empty(t, list.items)
+		assert.Nil(t, list.GetSelectedItem())
+	})
+}
+
 func TestLogViewer_Creation(t *testing.T) {
 	t.Run("should create log viewer with defaults", func(t *testing.T) {
 		theme := NewTheme()
@@ -718,6 +796,74 @@ func TestLogViewer_Update(t *testing.T) {
 	})
 }
 
+func TestLogViewer_MatchNavigation(t *testing.T) {
+	newSearchViewer := func() *LogViewer {
+		logViewer := NewLogViewer(NewTheme(), 6) // 2 visible lines
+		messages := []string{"deploy started", "cache warm", "deploy failed", "retrying", "deploy ok"}
+		for _, msg := range messages {
+			logViewer.AddLog(LogEntry{Timestamp: time.Now(), Level: "INFO", Component: "test", Message: msg})
+		}
+		logViewer.filter = "deploy"
+		logViewer.SetHideNonMatches(false)
+		return logViewer
+	}
+
+	t.Run("should step through matches and wrap at the end", func(t *testing.T) {
+		logViewer := newSearchViewer()
+		n := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}
+
+		expected := []int{0, 2, 4, 0}
+		for _, want := range expected {
+			logViewer, _ = logViewer.Update(n)
+			assert.Equal(t, want, logViewer.currentMatch)
+		}
+	})
+
+	t.Run("should step backwards and wrap at the start", func(t *testing.T) {
+		logViewer := newSearchViewer()
+		prev := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")}
+
+		expected := []int{4, 2, 0, 4}
+		for _, want := range expected {
+			logViewer, _ = logViewer.Update(prev)
+			assert.Equal(t, want, logViewer.currentMatch)
+		}
+	})
+
+	t.Run("should scroll the focused match into view", func(t *testing.T) {
+		logViewer := newSearchViewer()
+
+		logViewer.PrevMatch()
+
+		assert.Equal(t, 4, logViewer.currentMatch)
+		assert.Equal(t, 3, logViewer.scrollPos, "Last match should be the bottom visible line")
+		assert.Contains(t, logViewer.Render(80), "match 3/3")
+	})
+
+	t.Run("should keep non-matches visible in highlight-only mode", func(t *testing.T) {
+		logViewer := newSearchViewer()
+		logViewer.height = 20
+
+		rendered := logViewer.Render(80)
+		assert.Contains(t, rendered, "cache warm")
+		assert.Contains(t, rendered, "Search: 'deploy'")
+
+		logViewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+		rendered = logViewer.Render(80)
+		assert.NotContains(t, rendered, "cache warm", "Filter mode should hide non-matches")
+		assert.Contains(t, rendered, "Filter: 'deploy'")
+	})
+
+	t.Run("should ignore navigation without a query", func(t *testing.T) {
+		logViewer := newSearchViewer()
+		logViewer.filter = ""
+
+		logViewer.NextMatch()
+
+		assert.Equal(t, -1, logViewer.currentMatch)
+	})
+}
+
 func TestLogViewer_Render(t *testing.T) {
 	t.Run("should render empty log viewer", func(t *testing.T) {
 		theme := NewTheme()
@@ -45,6 +45,8 @@ type Dashboard struct {
 	selectedQuickAction int
 	showHelp            bool
 	lastRefresh         time.Time
+	claudeStatus        string // last connection check result, "" until checked
+	connectionState     string // health polling state, "" until the first poll
 }
 
 func NewDashboard(theme *Theme) *Dashboard {
@@ -76,8 +78,27 @@ func (d *Dashboard) SetSystemHealth(version string, serenaStatus string, activeA
 		ActiveAgents: activeAgents,
 		TotalAgents:  totalAgents,
 		SerenaStatus: serenaStatus,
-		ClaudeStatus: "active", // TODO: Get real Claude status
+		ClaudeStatus: "active",
 	}
+	if d.claudeStatus != "" {
+		d.systemHealth.ClaudeStatus = d.claudeStatus
+	}
+}
+
+// SetClaudeStatus records the result of a Claude connection check
+func (d *Dashboard) SetClaudeStatus(status string) {
+	d.claudeStatus = status
+	d.systemHealth.ClaudeStatus = status
+}
+
+// SetConnectionState records the health polling state (connected, degraded, disconnected)
+func (d *Dashboard) SetConnectionState(state string) {
+	d.connectionState = state
+}
+
+// ConnectionState returns the last health polling state
+func (d *Dashboard) ConnectionState() string {
+	return d.connectionState
 }
 
 // SetAgentStatuses updates agent statuses with real data
@@ -154,7 +175,11 @@ func (d *Dashboard) renderSystemHealth(width, height int) string {
 	content := ""
 
 	// MCF Version and uptime
-	content += d.theme.Body.Render(fmt.Sprintf("MCF %s", d.systemHealth.MCFVersion)) + "\n"
+	content += d.theme.Body.Render(fmt.Sprintf("MCF %s", d.systemHealth.MCFVersion))
+	if d.connectionState != "" {
+		content += "  " + RenderStatusIndicator(d.connectionState, d.theme)
+	}
+	content += "\n"
 	content += d.theme.Muted.Render(fmt.Sprintf("Uptime: %v", d.systemHealth.Uptime.Truncate(time.Second))) + "\n\n"
 
 	// System metrics with progress bars
@@ -232,16 +257,16 @@ func (d *Dashboard) renderRecentActivity(width, height int) string {
 		switch activity.Type {
 		case "command":
 			typeStyle = d.theme.Info
-			icon = "⚡"
+			icon = Icon("command")
 		case "agent":
 			typeStyle = d.theme.Success
-			icon = "🤖"
+			icon = Icon("agent")
 		case "error":
 			typeStyle = d.theme.Error
-			icon = "❌"
+			icon = Icon("error")
 		default:
 			typeStyle = d.theme.Muted
-			icon = "ℹ"
+			icon = Icon("info")
 		}
 
 		content += d.theme.Muted.Render(fmt.Sprintf("[%s] ", timestamp))
@@ -270,7 +295,7 @@ func (d *Dashboard) renderQuickActions(width, height int) string {
 		line := fmt.Sprintf("%s  %s", action.Key, action.Label)
 
 		if i == d.selectedQuickAction {
-			content += style.Render("► "+line) + "\n"
+			content += style.Render(Icon("cursor")+" "+line) + "\n"
 			content += d.theme.Muted.Render("  "+action.Description) + "\n"
 			content += d.theme.Info.Render("  "+action.Command) + "\n"
 		} else {
@@ -317,6 +342,7 @@ DASHBOARD:
   Enter            Execute selected action
   r                Refresh system status
   1-6              Quick action shortcuts
+  t                Test Claude connection
 
 QUICK ACTIONS:
   1  Agent Status   View all agent statuses
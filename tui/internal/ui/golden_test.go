@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	testutils "mcf-dev/tui/internal/testing"
+)
+
+// Fixed render size and clock so golden output is deterministic
+const (
+	goldenWidth  = 120
+	goldenHeight = 36
+)
+
+var goldenTime = time.Date(2025, 8, 30, 14, 30, 0, 0, time.UTC)
+
+// newGoldenDashboard builds a dashboard seeded with fixed data
+func newGoldenDashboard() *Dashboard {
+	dashboard := NewDashboard(NewTheme())
+	dashboard.SetSystemHealth("v1.0.0", "connected", 2, 3)
+	dashboard.SetAgentStatuses([]AgentStatus{
+		{Name: "orchestrator", Status: "active", LastSeen: goldenTime},
+		{Name: "test-engineer", Status: "active", LastSeen: goldenTime, TasksActive: 1, TasksTotal: 4},
+	})
+	dashboard.SetCommandHistory([]string{"serena:status", "project:analyze"})
+	dashboard.recentActivity = []RecentActivity{
+		{Timestamp: goldenTime, Type: "command", Message: "serena:status", Details: "Executed successfully"},
+		{Timestamp: goldenTime.Add(-time.Minute), Type: "error", Message: "project:deploy", Details: "Failed: exit status 1"},
+	}
+	dashboard.lastRefresh = goldenTime
+	return dashboard
+}
+
+// newGoldenLogViewer builds a log viewer seeded with fixed entries
+func newGoldenLogViewer() *LogViewer {
+	logViewer := NewLogViewer(NewTheme(), 12)
+	entries := []LogEntry{
+		{Timestamp: goldenTime, Level: "INFO", Component: "orchestrator", Message: "MCF system initialized"},
+		{Timestamp: goldenTime.Add(time.Second), Level: "WARN", Component: "test-engineer", Message: "Coverage below threshold"},
+		{Timestamp: goldenTime.Add(2 * time.Second), Level: "ERROR", Component: "deploy", Message: "Deployment failed"},
+	}
+	for _, entry := range entries {
+		logViewer.AddLog(entry)
+	}
+	return logViewer
+}
+
+func TestGolden_DashboardView(t *testing.T) {
+	t.Run("should match dashboard golden output", func(t *testing.T) {
+		testutils.AssertGolden(t, "dashboard", newGoldenDashboard().Render(goldenWidth, goldenHeight))
+	})
+
+	t.Run("should match dashboard help golden output", func(t *testing.T) {
+		dashboard := newGoldenDashboard()
+		dashboard.ToggleHelp()
+		testutils.AssertGolden(t, "dashboard_help", dashboard.Render(goldenWidth, goldenHeight))
+	})
+}
+
+func TestGolden_LogViewer(t *testing.T) {
+	t.Run("should match log viewer golden output", func(t *testing.T) {
+		testutils.AssertGolden(t, "log_viewer", newGoldenLogViewer().Render(goldenWidth))
+	})
+
+	t.Run("should match filtered log viewer golden output", func(t *testing.T) {
+		logViewer := newGoldenLogViewer()
+		logViewer.filter = "deploy"
+		testutils.AssertGolden(t, "log_viewer_filtered", logViewer.Render(goldenWidth))
+	})
+}
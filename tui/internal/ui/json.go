@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// jsonNode is a parsed JSON value that keeps object keys in document order
+type jsonNode struct {
+	kind     byte // '{', '[' or 0 for scalars
+	keys     []string
+	children []*jsonNode
+	scalar   string // raw JSON text for scalars
+}
+
+// IsJSON reports whether text is a JSON object or array
+func IsJSON(text string) bool {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return false
+	}
+	return json.Valid([]byte(trimmed))
+}
+
+// FormatJSON pretty-prints and highlights JSON text. Containers nested deeper than
+// maxDepth are collapsed to a summary; maxDepth <= 0 expands everything.
+// Non-JSON text is returned unchanged with ok=false.
+func FormatJSON(text string, maxDepth int, theme *Theme) (string, bool) {
+	if !IsJSON(text) {
+		return text, false
+	}
+
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+	node, err := parseJSONNode(dec)
+	if err != nil {
+		return text, false
+	}
+
+	var b strings.Builder
+	renderJSONNode(&b, node, 0, maxDepth, theme)
+	return b.String(), true
+}
+
+func parseJSONNode(dec *json.Decoder) (*jsonNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		node := &jsonNode{kind: byte(t)}
+		for dec.More() {
+			if node.kind == '{' {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.keys = append(node.keys, keyTok.(string))
+			}
+			child, err := parseJSONNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.children = append(node.children, child)
+		}
+		// Consume the closing delimiter
+		if _, err := dec.Token(); err != nil && err != io.EOF {
+			return nil, err
+		}
+		return node, nil
+	case string:
+		quoted, _ := json.Marshal(t)
+		return &jsonNode{scalar: string(quoted)}, nil
+	case nil:
+		return &jsonNode{scalar: "null"}, nil
+	default:
+		return &jsonNode{scalar: fmt.Sprint(t)}, nil
+	}
+}
+
+func renderJSONNode(b *strings.Builder, node *jsonNode, depth, maxDepth int, theme *Theme) {
+	if node.kind == 0 {
+		style := theme.Body
+		if strings.HasPrefix(node.scalar, `"`) {
+			style = theme.Success
+		}
+		b.WriteString(style.Render(node.scalar))
+		return
+	}
+
+	open, close := "{", "}"
+	if node.kind == '[' {
+		open, close = "[", "]"
+	}
+
+	if len(node.children) == 0 {
+		b.WriteString(open + close)
+		return
+	}
+	if maxDepth > 0 && depth >= maxDepth {
+		unit := "keys"
+		if node.kind == '[' {
+			unit = "items"
+		}
+		b.WriteString(theme.Muted.Render(fmt.Sprintf("%s…%d %s%s", open, len(node.children), unit, close)))
+		return
+	}
+
+	indent := strings.Repeat("  ", depth+1)
+	b.WriteString(open + "\n")
+	for i, child := range node.children {
+		b.WriteString(indent)
+		if node.kind == '{' {
+			key, _ := json.Marshal(node.keys[i])
+			b.WriteString(theme.Info.Render(string(key)) + ": ")
+		}
+		renderJSONNode(b, child, depth+1, maxDepth, theme)
+		if i < len(node.children)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(strings.Repeat("  ", depth) + close)
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	testutils "mcf-dev/tui/internal/testing"
+)
+
+func TestIsJSON(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`{"status": "ok"}`, true},
+		{"  [1, 2, 3]\n", true},
+		{`"just a string"`, false},
+		{"42", false},
+		{"Status: ok {not json}", false},
+		{`{"broken": }`, false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, IsJSON(tt.input), "IsJSON(%q)", tt.input)
+	}
+}
+
+func TestFormatJSON(t *testing.T) {
+	theme := NewTheme()
+
+	t.Run("should pretty-print keeping key order", func(t *testing.T) {
+		formatted, ok := FormatJSON(`{"zeta":1,"alpha":{"nested":[true,null]},"name":"mcf"}`, 0, theme)
+
+		assert.True(t, ok)
+		expected := "{\n" +
+			"  \"zeta\": 1,\n" +
+			"  \"alpha\": {\n" +
+			"    \"nested\": [\n" +
+			"      true,\n" +
+			"      null\n" +
+			"    ]\n" +
+			"  },\n" +
+			"  \"name\": \"mcf\"\n" +
+			"}"
+		assert.Equal(t, expected, testutils.StripANSI(formatted))
+	})
+
+	t.Run("should collapse containers below the depth limit", func(t *testing.T) {
+		formatted, ok := FormatJSON(`{"agents":[{"id":1},{"id":2}],"meta":{},"count":2}`, 1, theme)
+
+		assert.True(t, ok)
+		plain := testutils.StripANSI(formatted)
+		assert.Contains(t, plain, `"agents": […2 items]`)
+		assert.Contains(t, plain, `"meta": {}`, "Empty containers are shown as-is")
+		assert.Contains(t, plain, `"count": 2`)
+	})
+
+	t.Run("should leave non-JSON output untouched", func(t *testing.T) {
+		output := "Build complete\nwarnings: 0"
+
+		formatted, ok := FormatJSON(output, 0, theme)
+
+		assert.False(t, ok)
+		assert.Equal(t, output, formatted)
+	})
+}
@@ -124,7 +124,7 @@ func (n *Navigation) RenderBreadcrumb() string {
 	}
 
 	breadcrumbText := strings.Join(parts, n.theme.Muted.Render(" › "))
-	return n.theme.Breadcrumb.Render("📍 " + breadcrumbText)
+	return n.theme.Breadcrumb.Render(Icon("pin") + " " + breadcrumbText)
 }
 
 func (n *Navigation) RenderShortcuts() string {
@@ -209,6 +209,7 @@ func (n *Navigation) RenderHelp(width int) string {
 			"Enter - Execute selected action",
 			"r - Refresh system status",
 			"1-6 - Quick action shortcuts",
+			"t - Test Claude connection",
 		},
 		"Agents View": {
 			"j/k or ↑/↓ - Navigate agent list",
@@ -220,17 +221,36 @@ func (n *Navigation) RenderHelp(width int) string {
 		"Commands View": {
 			"j/k or ↑/↓ - Navigate command history",
 			"Enter - Re-execute command",
+			"v - Preview the resolved command, directory and environment",
+			"Esc/Ctrl+C - Cancel the running command",
+			"h - Toggle run history (Enter re-runs with the same arguments)",
 			"d - Delete command from history",
 			"c - Clear command history",
 			"/ - Search commands",
+			"f - Cycle category filter",
+			"p - Cycle output view: raw, pretty JSON, collapsed JSON",
+			"Space - Queue command for a bulk run",
+			"x - Run queued commands in order",
+			"o - Toggle stop/continue on failure",
+			"s - Toggle separate stdout/stderr output",
+			"w - Save queue as a playbook (:playbook <name> to replay)",
 		},
 		"Logs View": {
 			"j/k or ↑/↓ - Scroll logs",
 			"g/G - Go to top/bottom",
 			"/ - Search logs",
+			"n/N - Next/previous match",
+			"h - Toggle hiding non-matching lines",
 			"f - Follow/unfollow logs",
 			"c - Clear log view",
 		},
+		"Config View": {
+			"s - Show/close settings.json (read-only)",
+			"j/k or ↑/↓ - Scroll settings file",
+			"g/G - Go to top/bottom",
+			"/ - Search settings file",
+			"n/N - Next/previous match",
+		},
 	}
 
 	content := n.theme.Title.Render("MCF TUI Help") + "\n\n"
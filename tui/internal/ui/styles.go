@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -46,6 +49,9 @@ type Theme struct {
 	Error   lipgloss.Style
 	Info    lipgloss.Style
 
+	// Search match highlighting
+	Highlight lipgloss.Style
+
 	// Layout styles
 	Panel          lipgloss.Style
 	Card           lipgloss.Style
@@ -65,144 +71,190 @@ type Theme struct {
 	StatusUnknown lipgloss.Style
 }
 
+// palette is the set of colors a Theme is drawn with
+type palette struct {
+	Primary, Secondary, Accent       lipgloss.Color
+	Success, Warning, Error, Info    lipgloss.Color
+	Text, Muted, Border, Bg, Surface lipgloss.Color
+}
+
+// darkPalette is the default palette, built from the theme color variables
+func darkPalette() palette {
+	return palette{
+		Primary: PrimaryColor, Secondary: SecondaryColor, Accent: AccentColor,
+		Success: SuccessColor, Warning: WarningColor, Error: ErrorColor, Info: InfoColor,
+		Text: TextColor, Muted: MutedColor, Border: BorderColor, Bg: BgColor, Surface: SurfaceColor,
+	}
+}
+
+// lightPalette suits terminals with a light background
+func lightPalette() palette {
+	return palette{
+		Primary: "#6D28D9", Secondary: "#047857", Accent: "#B91C1C",
+		Success: "#15803D", Warning: "#A16207", Error: "#B91C1C", Info: "#0369A1",
+		Text: "#111827", Muted: "#6B7280", Border: "#D1D5DB", Bg: "#F9FAFB", Surface: "#FFFFFF",
+	}
+}
+
+// NewTheme returns the default dark theme
 func NewTheme() *Theme {
+	return newTheme(darkPalette())
+}
+
+// NewNamedTheme returns the theme for a tui.theme value, "dark" or "light"
+func NewNamedTheme(name string) (*Theme, error) {
+	switch name {
+	case "dark":
+		return newTheme(darkPalette()), nil
+	case "light":
+		return newTheme(lightPalette()), nil
+	}
+	return nil, fmt.Errorf("unknown theme %q (expected dark or light)", name)
+}
+
+// newTheme builds every style from one palette
+func newTheme(p palette) *Theme {
 	return &Theme{
 		// Base styles
 		Base: lipgloss.NewStyle().
-			Foreground(TextColor).
-			Background(BgColor),
+			Foreground(p.Text).
+			Background(p.Bg),
 
 		Surface: lipgloss.NewStyle().
-			Background(SurfaceColor).
+			Background(p.Surface).
 			Padding(1, 2),
 
 		Border: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(BorderColor),
+			BorderForeground(p.Border),
 
 		// Text styles
 		Title: lipgloss.NewStyle().
-			Foreground(PrimaryColor).
+			Foreground(p.Primary).
 			Bold(true).
 			MarginBottom(1),
 
 		Subtitle: lipgloss.NewStyle().
-			Foreground(SecondaryColor).
+			Foreground(p.Secondary).
 			Bold(true),
 
 		Body: lipgloss.NewStyle().
-			Foreground(TextColor),
+			Foreground(p.Text),
 
 		Muted: lipgloss.NewStyle().
-			Foreground(MutedColor),
+			Foreground(p.Muted),
 
 		// Interactive styles
 		Button: lipgloss.NewStyle().
-			Foreground(TextColor).
-			Background(SurfaceColor).
+			Foreground(p.Text).
+			Background(p.Surface).
 			Padding(0, 2).
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(BorderColor),
+			BorderForeground(p.Border),
 
 		ButtonActive: lipgloss.NewStyle().
-			Foreground(BgColor).
-			Background(PrimaryColor).
+			Foreground(p.Bg).
+			Background(p.Primary).
 			Padding(0, 2).
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(PrimaryColor),
+			BorderForeground(p.Primary),
 
 		Input: lipgloss.NewStyle().
-			Foreground(TextColor).
-			Background(SurfaceColor).
+			Foreground(p.Text).
+			Background(p.Surface).
 			Padding(0, 1).
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(BorderColor),
+			BorderForeground(p.Border),
 
 		InputFocused: lipgloss.NewStyle().
-			Foreground(TextColor).
-			Background(SurfaceColor).
+			Foreground(p.Text).
+			Background(p.Surface).
 			Padding(0, 1).
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(PrimaryColor),
+			BorderForeground(p.Primary),
 
 		// Status styles
 		Success: lipgloss.NewStyle().
-			Foreground(SuccessColor).
+			Foreground(p.Success).
 			Bold(true),
 
 		Warning: lipgloss.NewStyle().
-			Foreground(WarningColor).
+			Foreground(p.Warning).
 			Bold(true),
 
 		Error: lipgloss.NewStyle().
-			Foreground(ErrorColor).
+			Foreground(p.Error).
 			Bold(true),
 
 		Info: lipgloss.NewStyle().
-			Foreground(InfoColor).
+			Foreground(p.Info).
 			Bold(true),
 
+		Highlight: lipgloss.NewStyle().
+			Foreground(p.Bg).
+			Background(p.Warning),
+
 		// Layout styles
 		Panel: lipgloss.NewStyle().
-			Background(SurfaceColor).
+			Background(p.Surface).
 			Padding(1, 2).
 			Margin(1, 0).
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(BorderColor),
+			BorderForeground(p.Border),
 
 		Card: lipgloss.NewStyle().
-			Background(SurfaceColor).
+			Background(p.Surface).
 			Padding(1, 2).
 			Margin(0, 1).
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(BorderColor),
+			BorderForeground(p.Border),
 
 		List: lipgloss.NewStyle().
-			Background(SurfaceColor).
+			Background(p.Surface).
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(BorderColor),
+			BorderForeground(p.Border),
 
 		ListItem: lipgloss.NewStyle().
-			Foreground(TextColor).
+			Foreground(p.Text).
 			Padding(0, 1),
 
 		ListItemActive: lipgloss.NewStyle().
-			Foreground(BgColor).
-			Background(PrimaryColor).
+			Foreground(p.Bg).
+			Background(p.Primary).
 			Padding(0, 1).
 			Bold(true),
 
 		// Navigation styles
 		TabActive: lipgloss.NewStyle().
-			Foreground(BgColor).
-			Background(PrimaryColor).
+			Foreground(p.Bg).
+			Background(p.Primary).
 			Padding(0, 2).
 			Bold(true),
 
 		TabInactive: lipgloss.NewStyle().
-			Foreground(MutedColor).
-			Background(SurfaceColor).
+			Foreground(p.Muted).
+			Background(p.Surface).
 			Padding(0, 2),
 
 		Breadcrumb: lipgloss.NewStyle().
-			Foreground(MutedColor).
+			Foreground(p.Muted).
 			MarginBottom(1),
 
 		// Progress and status
 		ProgressBar: lipgloss.NewStyle().
-			Background(SurfaceColor).
-			Foreground(PrimaryColor),
+			Background(p.Surface).
+			Foreground(p.Primary),
 
 		StatusGood: lipgloss.NewStyle().
-			Foreground(SuccessColor).
+			Foreground(p.Success).
 			Bold(true),
 
 		StatusBad: lipgloss.NewStyle().
-			Foreground(ErrorColor).
+			Foreground(p.Error).
 			Bold(true),
 
 		StatusUnknown: lipgloss.NewStyle().
-			Foreground(MutedColor).
+			Foreground(p.Muted).
 			Bold(true),
 	}
 }
@@ -236,9 +288,9 @@ func RenderProgressBar(progress float64, width int, theme *Theme) string {
 	bar := ""
 	for i := 0; i < width; i++ {
 		if i < filled {
-			bar += "█"
+			bar += Icon("filled")
 		} else {
-			bar += "░"
+			bar += Icon("empty")
 		}
 	}
 
@@ -248,12 +300,13 @@ func RenderProgressBar(progress float64, width int, theme *Theme) string {
 // Status indicator rendering
 func RenderStatusIndicator(status string, theme *Theme) string {
 	switch status {
-	case "active", "running", "healthy", "connected", "online":
-		return theme.StatusGood.Render("● " + status)
-	case "inactive", "stopped", "unhealthy", "disconnected", "offline", "error", "failed":
-		return theme.StatusBad.Render("● " + status)
+	case "active", "running", "healthy", "connected", "online", "authenticated", "passed":
+		return theme.StatusGood.Render(Icon("status") + " " + status)
+	case "inactive", "stopped", "unhealthy", "disconnected", "offline", "error", "failed",
+		"unauthenticated", "missing-key", "not-installed", "unreachable":
+		return theme.StatusBad.Render(Icon("status") + " " + status)
 	default:
-		return theme.StatusUnknown.Render("● " + status)
+		return theme.StatusUnknown.Render(Icon("status") + " " + status)
 	}
 }
 
@@ -270,3 +323,30 @@ func RenderBox(content string, title string, width, height int, theme *Theme) st
 
 	return style.Render(content)
 }
+
+// HighlightYAML colors mapping keys in YAML text for read-only display
+func HighlightYAML(text string, theme *Theme) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+
+	for i, line := range lines {
+		body := strings.TrimLeft(line, " ")
+		indent := line[:len(line)-len(body)]
+		if strings.HasPrefix(body, "- ") {
+			indent += "- "
+			body = body[2:]
+		}
+
+		sep := strings.Index(body, ": ")
+		if sep < 0 && strings.HasSuffix(body, ":") {
+			sep = len(body) - 1
+		}
+		if sep <= 0 || strings.HasPrefix(body, "#") {
+			lines[i] = indent + theme.Body.Render(body)
+			continue
+		}
+
+		lines[i] = indent + theme.Info.Render(body[:sep+1]) + theme.Body.Render(body[sep+1:])
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ansiPattern matches SGR escape sequences so search ignores styling
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// TextViewer is a read-only scrollable text pane with search, wrapping a viewport.
+// Keys: j/k or ↑/↓ scroll, pgup/pgdown page, g/G jump to top/bottom, / search, n/N next/previous match.
+type TextViewer struct {
+	theme    *Theme
+	viewport viewport.Model
+	plain    []string // content lines without styling, for search
+
+	query        string
+	matches      []int // line indexes containing query
+	currentMatch int
+	searchMode   bool
+	searchInput  textinput.Model
+}
+
+// NewTextViewer creates an empty viewer of the given size
+func NewTextViewer(theme *Theme, width, height int) *TextViewer {
+	searchInput := textinput.New()
+	searchInput.Placeholder = "Search..."
+
+	return &TextViewer{
+		theme:        theme,
+		viewport:     viewport.New(max(width, 1), max(height, 1)),
+		currentMatch: -1,
+		searchInput:  searchInput,
+	}
+}
+
+// SetSize resizes the visible area, keeping the scroll position in bounds
+func (v *TextViewer) SetSize(width, height int) {
+	v.viewport.Width = max(width, 1)
+	v.viewport.Height = max(height, 1)
+	v.viewport.SetYOffset(v.viewport.YOffset)
+}
+
+// SetContent replaces the text, scrolling back to the top and re-running the search
+func (v *TextViewer) SetContent(text string) {
+	text = strings.TrimRight(text, "\n")
+	v.viewport.SetContent(text)
+	v.viewport.GotoTop()
+	v.plain = strings.Split(ansiPattern.ReplaceAllString(text, ""), "\n")
+	v.Search(v.query)
+}
+
+// Search finds lines containing query (case-insensitive) and jumps to the first one.
+// It returns the number of matching lines; an empty query clears the search.
+func (v *TextViewer) Search(query string) int {
+	v.query = query
+	v.matches = nil
+	v.currentMatch = -1
+	if query == "" {
+		return 0
+	}
+
+	needle := strings.ToLower(query)
+	for i, line := range v.plain {
+		if strings.Contains(strings.ToLower(line), needle) {
+			v.matches = append(v.matches, i)
+		}
+	}
+	if len(v.matches) > 0 {
+		v.focusMatch(0)
+	}
+	return len(v.matches)
+}
+
+// NextMatch scrolls to the next search match, wrapping around
+func (v *TextViewer) NextMatch() {
+	if len(v.matches) > 0 {
+		v.focusMatch((v.currentMatch + 1) % len(v.matches))
+	}
+}
+
+// PrevMatch scrolls to the previous search match, wrapping around
+func (v *TextViewer) PrevMatch() {
+	if len(v.matches) > 0 {
+		v.focusMatch((v.currentMatch - 1 + len(v.matches)) % len(v.matches))
+	}
+}
+
+func (v *TextViewer) focusMatch(idx int) {
+	v.currentMatch = idx
+	v.viewport.SetYOffset(v.matches[idx])
+}
+
+// YOffset returns the index of the first visible line
+func (v *TextViewer) YOffset() int {
+	return v.viewport.YOffset
+}
+
+// Searching reports whether the search input has focus
+func (v *TextViewer) Searching() bool {
+	return v.searchMode
+}
+
+// Update handles scrolling and search keys
+func (v *TextViewer) Update(msg tea.KeyMsg) tea.Cmd {
+	if v.searchMode {
+		switch msg.String() {
+		case "enter":
+			v.searchMode = false
+			v.searchInput.Blur()
+			v.Search(v.searchInput.Value())
+		case "esc":
+			v.searchMode = false
+			v.searchInput.Blur()
+		default:
+			var cmd tea.Cmd
+			v.searchInput, cmd = v.searchInput.Update(msg)
+			return cmd
+		}
+		return nil
+	}
+
+	switch msg.String() {
+	case "j", "down":
+		v.viewport.LineDown(1)
+	case "k", "up":
+		v.viewport.LineUp(1)
+	case "pgdown":
+		v.viewport.ViewDown()
+	case "pgup":
+		v.viewport.ViewUp()
+	case "g", "home":
+		v.viewport.GotoTop()
+	case "G", "end":
+		v.viewport.GotoBottom()
+	case "n":
+		v.NextMatch()
+	case "N":
+		v.PrevMatch()
+	case "/":
+		v.searchMode = true
+		v.searchInput.SetValue(v.query)
+		v.searchInput.Focus()
+	}
+	return nil
+}
+
+// StatusLine describes the visible range and search state
+func (v *TextViewer) StatusLine() string {
+	total := v.viewport.TotalLineCount()
+	start := min(v.viewport.YOffset+1, total)
+	end := min(v.viewport.YOffset+v.viewport.Height, total)
+	status := fmt.Sprintf("Lines %d-%d of %d", start, end, total)
+
+	if v.query != "" {
+		if len(v.matches) == 0 {
+			status += fmt.Sprintf(" │ no matches for %q", v.query)
+		} else {
+			status += fmt.Sprintf(" │ match %d/%d for %q", v.currentMatch+1, len(v.matches), v.query)
+		}
+	}
+	return status
+}
+
+// View renders the visible lines, with the search input below while searching
+func (v *TextViewer) View() string {
+	if v.searchMode {
+		return v.viewport.View() + "\n" + v.searchInput.View()
+	}
+	return v.viewport.View()
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+// numberedLines returns n lines "line 1" .. "line n"
+func numberedLines(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func keyPress(key string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+func TestTextViewer_Scrolling(t *testing.T) {
+	t.Run("should stay within bounds", func(t *testing.T) {
+		viewer := NewTextViewer(NewTheme(), 40, 5)
+		viewer.SetContent(numberedLines(12))
+
+		viewer.Update(keyPress("k"))
+		assert.Equal(t, 0, viewer.YOffset(), "Should not scroll above the top")
+
+		for i := 0; i < 20; i++ {
+			viewer.Update(keyPress("j"))
+		}
+		assert.Equal(t, 7, viewer.YOffset(), "Should stop with the last line at the bottom")
+		assert.Equal(t, "Lines 8-12 of 12", viewer.StatusLine())
+
+		viewer.Update(keyPress("g"))
+		assert.Equal(t, 0, viewer.YOffset())
+		viewer.Update(keyPress("G"))
+		assert.Equal(t, 7, viewer.YOffset())
+	})
+
+	t.Run("should not scroll content that fits", func(t *testing.T) {
+		viewer := NewTextViewer(NewTheme(), 40, 10)
+		viewer.SetContent(numberedLines(3))
+
+		viewer.Update(keyPress("j"))
+
+		assert.Equal(t, 0, viewer.YOffset())
+		assert.Equal(t, "Lines 1-3 of 3", viewer.StatusLine())
+	})
+
+	t.Run("should clamp the offset when resized", func(t *testing.T) {
+		viewer := NewTextViewer(NewTheme(), 40, 5)
+		viewer.SetContent(numberedLines(12))
+		viewer.Update(keyPress("G"))
+
+		viewer.SetSize(40, 10)
+
+		assert.Equal(t, 2, viewer.YOffset())
+	})
+}
+
+func TestTextViewer_Search(t *testing.T) {
+	t.Run("should jump between case-insensitive matches and wrap", func(t *testing.T) {
+		theme := NewTheme()
+		viewer := NewTextViewer(theme, 40, 2)
+		viewer.SetContent("alpha\n" + theme.Info.Render("Beta") + "\ngamma\nbeta again\ndelta")
+
+		assert.Equal(t, 2, viewer.Search("BETA"))
+		assert.Equal(t, 1, viewer.YOffset(), "Should focus the first match")
+		assert.Contains(t, viewer.StatusLine(), `match 1/2 for "BETA"`)
+
+		viewer.Update(keyPress("n"))
+		assert.Equal(t, 3, viewer.YOffset())
+		viewer.Update(keyPress("n"))
+		assert.Equal(t, 1, viewer.YOffset(), "Should wrap to the first match")
+		viewer.Update(keyPress("N"))
+		assert.Equal(t, 3, viewer.YOffset(), "Should wrap to the last match")
+	})
+
+	t.Run("should search through the input", func(t *testing.T) {
+		viewer := NewTextViewer(NewTheme(), 40, 2)
+		viewer.SetContent("one\ntwo\nthree")
+
+		viewer.Update(keyPress("/"))
+		assert.True(t, viewer.Searching())
+		for _, r := range "thr" {
+			viewer.Update(keyPress(string(r)))
+		}
+		viewer.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		assert.False(t, viewer.Searching())
+		assert.Equal(t, 1, viewer.YOffset(), "Line 3 is shown at the bottom of a 2-line view")
+		assert.Contains(t, viewer.StatusLine(), `match 1/1 for "thr"`)
+	})
+
+	t.Run("should report no matches", func(t *testing.T) {
+		viewer := NewTextViewer(NewTheme(), 40, 2)
+		viewer.SetContent("one\ntwo")
+
+		assert.Equal(t, 0, viewer.Search("zzz"))
+		assert.Contains(t, viewer.StatusLine(), `no matches for "zzz"`)
+		viewer.Update(keyPress("n"))
+		assert.Equal(t, 0, viewer.YOffset())
+	})
+}